@@ -0,0 +1,40 @@
+package gotris
+
+import "github.com/ShawnROGrady/gotris/internal/game"
+
+// Action is something the player can do to the current piece
+type Action int
+
+// the available actions
+const (
+	MoveLeft Action = iota
+	MoveRight
+	// SoftDrop moves the piece down a single row, locking it in place if it's already at the bottom
+	SoftDrop
+	// HardDrop drops the piece as far as it can go then locks it in place
+	HardDrop
+	RotateLeft
+	RotateRight
+)
+
+var internalActions = map[Action]game.Action{
+	MoveLeft:    game.MoveLeft,
+	MoveRight:   game.MoveRight,
+	SoftDrop:    game.MoveDown,
+	HardDrop:    game.MoveUp,
+	RotateLeft:  game.RotateLeft,
+	RotateRight: game.RotateRight,
+}
+
+func (a Action) String() string {
+	actionNames := map[Action]string{
+		MoveLeft:    "move left",
+		MoveRight:   "move right",
+		SoftDrop:    "soft drop",
+		HardDrop:    "hard drop",
+		RotateLeft:  "rotate left",
+		RotateRight: "rotate right",
+	}
+
+	return actionNames[a]
+}
@@ -1,15 +1,80 @@
 package main
 
 import (
-	"log"
+	"fmt"
+	"io/ioutil"
 	"os"
 
+	"github.com/ShawnROGrady/gotris/internal/canvas"
 	"github.com/ShawnROGrady/gotris/internal/game"
+	"github.com/ShawnROGrady/gotris/internal/game/tetrimino"
+	"github.com/ShawnROGrady/gotris/internal/theme"
 )
 
-func printPotentialColors(opts ...game.Option) {
-	demo := game.New(nil, os.Stdout, opts...)
-	if err := demo.DisplayPotentialColors(); err != nil {
-		log.Fatalf("Error displaying potential colors: %s", err)
+// printPotentialColors displays a demo board with the potential pieces and colors
+// if no theme was specified then every built in theme is displayed, unless colors are disabled since they would all look the same
+func printPotentialColors(s settings) error {
+	opts, err := s.options()
+	if err != nil {
+		return err
 	}
+
+	themes := theme.Available()
+	if s.Theme != "" || s.LightMode || s.LowContrast || s.colorProfile == canvas.Monochrome {
+		selected, err := s.SelectedTheme()
+		if err != nil {
+			return err
+		}
+		themes = []theme.Theme{selected}
+	}
+
+	// the preview should remain visible after exiting
+	c := canvas.New(os.Stdout, canvas.WithoutAltScreen(), canvas.WithColorProfile(s.colorProfile))
+	if err := c.Init(); err != nil {
+		return err
+	}
+
+	cells := [][]canvas.Cell{}
+	for _, t := range themes {
+		demo := game.New(nil, ioutil.Discard, append(opts, game.WithTheme(t))...)
+		cells = append(cells, canvas.CellsFromString(fmt.Sprintf("Theme: %s", t), t.Text)...)
+		cells = append(cells, demo.PotentialColorCells()...)
+	}
+	cells = append(cells, ghostStyleCells(themes[0])...)
+
+	c.UpdateCells(cells)
+	return c.Render()
+}
+
+// ghostStyleCells displays a sample of every ghost style, along with the theme's ghost style if it is a custom glyph
+func ghostStyleCells(t theme.Theme) [][]canvas.Cell {
+	styles := canvas.GhostStyles()
+	if t.Ghost != "" && !containsGhostStyle(styles, t.Ghost) {
+		styles = append(styles, t.Ghost)
+	}
+
+	cells := canvas.CellsFromString("Ghost styles:", t.Text)
+	for _, style := range styles {
+		row := canvas.CellsFromString(fmt.Sprintf("%-8s", style), t.Text)[0]
+		for i := 0; i < 4; i++ {
+			row = append(row, &canvas.BlockCell{
+				Color:       t.Pieces[tetrimino.TPiece],
+				Background:  t.Background,
+				Transparent: true,
+				Ghost:       style,
+				Label:       tetrimino.TPiece.String(),
+			})
+		}
+		cells = append(cells, row)
+	}
+	return cells
+}
+
+func containsGhostStyle(styles []canvas.GhostStyle, style canvas.GhostStyle) bool {
+	for _, s := range styles {
+		if s == style {
+			return true
+		}
+	}
+	return false
 }
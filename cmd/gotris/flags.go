@@ -1,6 +1,13 @@
 package main
 
-import "strings"
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ShawnROGrady/gotris/internal/config"
+)
 
 type stringArrayFlag []string
 
@@ -12,3 +19,78 @@ func (s *stringArrayFlag) Set(val string) error {
 	*s = append(*s, val)
 	return nil
 }
+
+// applyFlags overrides the config with any flags which were explicitly set
+func applyFlags(c *config.Config, schemes []string) error {
+	var err error
+	flag.Visit(func(f *flag.Flag) {
+		if err != nil {
+			return
+		}
+		err = applyFlag(c, f, schemes)
+	})
+	return err
+}
+
+func applyFlag(c *config.Config, f *flag.Flag, schemes []string) error {
+	var (
+		value = f.Value.String()
+		err   error
+	)
+
+	switch f.Name {
+	case "scheme":
+		c.Schemes = schemes
+	case "mode":
+		c.Mode = value
+	case "difficulty":
+		c.Difficulty = value
+	case "width":
+		c.Width, err = strconv.Atoi(value)
+	case "height":
+		c.Height, err = strconv.Atoi(value)
+	case "disable-ghost":
+		c.DisableGhost, err = strconv.ParseBool(value)
+	case "disable-side":
+		c.DisableSide, err = strconv.ParseBool(value)
+	case "panels":
+		c.Panels = splitList(value)
+	case "sidebar":
+		c.Sidebar = value
+	case "light-mode":
+		// the theme from the config file would otherwise take precedence
+		c.LightMode, err = strconv.ParseBool(value)
+		c.Theme = ""
+	case "low-contrast":
+		c.LowContrast, err = strconv.ParseBool(value)
+		c.Theme = ""
+	case "theme":
+		c.Theme = value
+	case "ghost-style":
+		c.GhostStyle = value
+	case "half-blocks":
+		c.HalfBlocks, err = strconv.ParseBool(value)
+	case "monochrome":
+		c.Monochrome, err = strconv.ParseBool(value)
+	case "partial-lock-out":
+		c.PartialLockOut, err = strconv.ParseBool(value)
+	case "max-fps":
+		c.MaxFPS, err = strconv.Atoi(value)
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid value for -%s: %s", f.Name, err)
+	}
+	return nil
+}
+
+// splitList splits a comma separated list, an empty string being an empty list
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
@@ -8,89 +9,137 @@ import (
 	"os/signal"
 	"runtime/pprof"
 	"strings"
+	"sync"
 	"syscall"
 
 	"github.com/ShawnROGrady/gotris/internal/canvas"
+	"github.com/ShawnROGrady/gotris/internal/config"
 	"github.com/ShawnROGrady/gotris/internal/game"
+	"github.com/ShawnROGrady/gotris/internal/highscore"
+	"github.com/ShawnROGrady/gotris/internal/inputreader"
+	"github.com/ShawnROGrady/gotris/internal/term"
 )
 
 func main() {
 	schemeArgs := &stringArrayFlag{}
 	colorTest := flag.Bool("colors", false, "Display the colors that will be used throughout the game then exit")
 	debugMode := flag.Bool("debug", false, "Run the game in debug mode. This disables gravity as well as canvas clearing")
-	disableGhost := flag.Bool("disable-ghost", false, "Don't show the 'ghost' of the current piece")
-	disableSide := flag.Bool("disable-side", false, "Don't show the side bar (next piece, current score, and controls)")
+	flag.Bool("disable-ghost", false, "Don't show the 'ghost' of the current piece")
+	flag.Bool("disable-side", false, "Don't show the side bar (next piece, current score, and controls)")
+	flag.String("panels", "", fmt.Sprintf("comma separated list of the panels to show in the side bar, in order (options = %s) (default next,score,controls)", strings.Join(panelNames(), ", ")))
+	flag.String("sidebar", game.SidebarRight.String(), "the side of the board to show the side bar on (options = left, right)")
 	flag.Var(schemeArgs, "scheme", fmt.Sprintf("The control scheme to use, multiple may be specified (default: %s)", game.HomeRowName))
 	describeScheme := flag.Bool("describe-scheme", false, "Prints the specified control scheme then exits. If none specified then all available schemes are described")
-	lightMode := flag.Bool("light-mode", false, "Update colors to work for light color schemes")
-	lowContrastMode := flag.Bool("low-contrast", false, "Update colors to use lower contrast (updates background to white for 'light-mode', black otherwise)")
+	flag.Bool("light-mode", false, "Update colors to work for light color schemes")
+	flag.Bool("low-contrast", false, "Update colors to use lower contrast (updates background to white for 'light-mode', black otherwise)")
+	flag.String("theme", "", fmt.Sprintf("the color theme, either a built-in theme (options = %s) or the path to a theme file", strings.Join(themeNames(), ", ")))
+	flag.String("ghost-style", "", fmt.Sprintf("how the ghost piece is drawn, overriding the theme (options = %s, or any single-column character)", strings.Join(ghostStyleNames(), ", ")))
+	flag.Bool("half-blocks", false, "Draw two rows of the board in each row of the terminal, which fits larger boards in smaller terminals")
+	flag.Bool("monochrome", false, "Don't use any colors, pieces are drawn using their letter and borders using plain ASCII characters")
 	cpuprofile := flag.String("cpuprofile", "", "write cpu profile to specified file")
-	difficulty := flag.String("difficulty", game.BeginnerDifficulty, fmt.Sprintf("the initial difficulty (options = %s)", strings.Join([]string{game.BeginnerDifficulty, game.NoviceDifficulty, game.ProDifficulty, game.ExpertDifficulty}, ", ")))
+	flag.Bool("partial-lock-out", false, "End the game if a piece locks partially above the visible field")
+	flag.Int("max-fps", game.DefaultMaxFPS, "the maximum number of frames rendered per second (0 = unlimited)")
+	flag.String("mode", game.MarathonMode, fmt.Sprintf("the game mode (options = %s)", strings.Join(modes(), ", ")))
+	flag.Int("width", 0, "the width of the board (default 10)")
+	flag.Int("height", 0, "the height of the board (default 20)")
+	seed := flag.Int64("seed", 0, "the seed used to generate the order of the pieces, e.g. to play the pieces of a previous game again (0 = random)")
+	jsonOutput := flag.Bool("json", false, "Print the result of the final game as JSON instead of a summary")
+	showScores := flag.Bool("scores", false, "Prints the high score table then exits")
+	noMenu := flag.Bool("no-menu", false, "Start the game immediately instead of displaying the main menu")
+	configPath := flag.String("config", "", "the configuration file to use (default: $XDG_CONFIG_HOME/gotris/config.json)")
+	writeConfig := flag.Bool("write-config", false, "Prints the effective configuration (config file + flags) then exits")
+	flag.String("difficulty", game.BeginnerDifficulty, fmt.Sprintf("the initial difficulty (options = %s)", strings.Join(difficulties(), ", ")))
 
 	flag.Parse()
 
-	// set the specified control scheme
-	var scheme game.ControlSchemes
-	for _, arg := range *schemeArgs {
-		s, err := game.SchemeFromName(arg)
+	if *configPath == "" {
+		path, err := config.DefaultPath()
 		if err != nil {
-			log.Fatalf("%s", err)
+			log.Fatalf("Error finding config file: %s", err)
 			os.Exit(1)
 		}
-		scheme = append(scheme, s)
+		*configPath = path
 	}
 
-	opts := []game.Option{}
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("Error loading config: %s", err)
+		os.Exit(1)
+	}
 
-	if difficulty != nil {
-		initLevel, err := game.LevelFromDifficulty(*difficulty)
-		if err != nil {
-			log.Fatalf("%s", err)
-			os.Exit(1)
-		}
-		opts = append(opts, game.WithInitialLevel(initLevel))
+	// flags take precedence over the config file
+	if err := applyFlags(&cfg, *schemeArgs); err != nil {
+		log.Fatalf("%s", err)
+		os.Exit(1)
 	}
 
-	if lightMode != nil && *lightMode {
-		background := canvas.Black
-		if lowContrastMode != nil && *lowContrastMode {
-			background = canvas.White
+	if writeConfig != nil && *writeConfig {
+		if _, err := cfg.Options(); err != nil {
+			log.Fatalf("Invalid config: %s", err)
+			os.Exit(1)
 		}
-		opts = append(opts, game.WithBackground(background))
-		opts = append(opts, game.WithColor(canvas.Black))
-	} else {
-		if lowContrastMode != nil && *lowContrastMode {
-			opts = append(opts, game.WithBackground(canvas.Black))
+		if err := cfg.Write(os.Stdout); err != nil {
+			log.Fatalf("Error writing config: %s", err)
+			os.Exit(1)
 		}
+		os.Exit(0)
 	}
 
-	if disableGhost != nil && *disableGhost {
-		opts = append(opts, game.WithoutGhost())
-	}
-
-	if disableSide != nil && *disableSide {
-		opts = append(opts, game.WithoutSide())
-	}
+	s := settings{Config: cfg, colorProfile: cfg.ColorProfile(canvas.DetectColorProfile())}
 
 	if colorTest != nil && *colorTest {
-		printPotentialColors(opts...)
+		if err := printPotentialColors(s); err != nil {
+			log.Fatalf("%s", err)
+			os.Exit(1)
+		}
 		os.Exit(0)
 	}
 
 	if debugMode != nil && *debugMode {
-		opts = append(opts, game.WithDebugMode())
+		s.debugMode = true
+	}
+	if seed != nil {
+		s.seed = *seed
 	}
 
 	if describeScheme != nil && *describeScheme {
-		describeSchemes(scheme)
+		scheme, err := cfg.ControlSchemes()
+		if err != nil {
+			log.Fatalf("%s", err)
+			os.Exit(1)
+		}
+		allSchemes, err := cfg.AvailableSchemes()
+		if err != nil {
+			log.Fatalf("%s", err)
+			os.Exit(1)
+		}
+		describeSchemes(scheme, allSchemes)
 		os.Exit(0)
 	}
 
-	if scheme == nil {
-		// default to home row if no scheme provided
-		scheme = game.ControlSchemes{game.HomeRow()}
+	var scores *highscore.Table
+	scoresPath, err := highscore.DefaultPath()
+	if err == nil {
+		scores, err = highscore.Load(scoresPath, highscore.DefaultSize)
+	}
+	if err != nil {
+		// still allow playing without high scores
+		log.Printf("Error loading high scores: %s", err)
+	}
+
+	if showScores != nil && *showScores {
+		if scores == nil {
+			os.Exit(1)
+		}
+		printScores(scores)
+		os.Exit(0)
+	}
+
+	// validate settings before setting up the terminal
+	if _, err := s.options(); err != nil {
+		log.Fatalf("%s", err)
+		os.Exit(1)
 	}
-	opts = append(opts, game.WithControlScheme(scheme))
 
 	if cpuprofile != nil && *cpuprofile != "" {
 		f, err := os.Create(*cpuprofile)
@@ -104,17 +153,6 @@ func main() {
 		defer pprof.StopCPUProfile()
 	}
 
-	undoSetup, err := setupTerm()
-	if err != nil {
-		log.Fatalf("error setting up terminal: %s", err)
-		os.Exit(1)
-	}
-	defer func() {
-		if err := undoSetup(); err != nil {
-			log.Fatalf("Error undoing set up: %s", err)
-		}
-	}()
-
 	fRead, err := os.OpenFile("/dev/tty", os.O_RDONLY, 0755)
 	if err != nil {
 		log.Fatalf("Error opening controlling terminal to read from: %s", err)
@@ -127,28 +165,75 @@ func main() {
 	}
 	defer fWrite.Close()
 
-	g := game.New(fRead, fWrite, opts...)
+	oldState, err := term.MakeGameMode(fRead.Fd())
+	if err != nil {
+		log.Fatalf("Error setting up terminal: %s", err)
+	}
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	resize := make(chan os.Signal, 1)
+	signal.Notify(resize, syscall.SIGWINCH)
 
-	done := make(chan bool)
-	defer close(done)
+	size := func() (int, int) {
+		width, height, err := term.Size(fWrite.Fd())
+		if err != nil {
+			// an unknown size (0x0) uses the preferred layout
+			return 0, 0
+		}
+		return width, height
+	}
 
-	endScore, runErr := g.Run(done)
+	t := &terminal{
+		reader:     inputreader.NewTermReader(fRead),
+		writer:     fWrite,
+		canvas:     canvas.New(fWrite, canvas.WithColorProfile(s.colorProfile)),
+		sigs:       sigs,
+		resize:     resize,
+		size:       size,
+		showMenu:   !*noMenu,
+		scores:     scores,
+		scoresPath: scoresPath,
+	}
 
-	sigs := make(chan os.Signal, 1)
-	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	var restoreOnce sync.Once
+	// restore leaves the alternate screen and restores the original terminal mode
+	// it is deferred so that the terminal is also restored when panicking, but must be called explicitly
+	// before printing the final output (so it isn't lost with the alternate screen) or calling log.Fatalf
+	restore := func() {
+		restoreOnce.Do(func() {
+			if err := t.canvas.Close(); err != nil {
+				log.Printf("Error closing canvas: %s", err)
+			}
+			if err := term.Restore(fRead.Fd(), oldState); err != nil {
+				log.Printf("Error restoring terminal: %s", err)
+			}
+		})
+	}
+	defer restore()
 
-	select {
-	case <-done:
-		return
-	case err := <-runErr:
+	// the terminal's canvas switches to the alternate screen for the whole session, and leaves it once restored
+	if err := t.canvas.Init(); err != nil {
+		restore()
+		log.Fatalf("Error initializing canvas: %s", err)
+	}
+
+	result, err := t.session(s)
+	restore()
+	if err != nil {
+		if sigErr, ok := err.(signalError); ok {
+			fmt.Println(sigErr)
+			return
+		}
 		log.Fatalf("Error running game: %s", err)
-		os.Exit(1)
-	case score := <-endScore:
-		fmt.Printf("GAME OVER (score = %d)\n", score)
+	}
+	if result.Reason == game.NotOver {
 		return
-	case sig := <-sigs:
-		fmt.Printf("received signal: %s\n", sig)
+	}
+	if jsonOutput != nil && *jsonOutput {
+		if err := json.NewEncoder(os.Stdout).Encode(result.Result); err != nil {
+			log.Fatalf("Error encoding result: %s", err)
+		}
 		return
 	}
-
+	fmt.Printf("GAME OVER\n%s\n", result.Result)
 }
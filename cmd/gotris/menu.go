@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ShawnROGrady/gotris/internal/canvas"
+	"github.com/ShawnROGrady/gotris/internal/layout"
+)
+
+type menuInput int
+
+const (
+	menuUp menuInput = iota
+	menuDown
+	menuLeft
+	menuRight
+	menuSelect
+)
+
+// the keys used to navigate menus, regardless of the selected control scheme
+var menuControls = map[string]menuInput{
+	"k":        menuUp,
+	"w":        menuUp,
+	"\u001b[A": menuUp,
+	"j":        menuDown,
+	"s":        menuDown,
+	"\u001b[B": menuDown,
+	"h":        menuLeft,
+	"a":        menuLeft,
+	"\u001b[D": menuLeft,
+	"l":        menuRight,
+	"d":        menuRight,
+	"\u001b[C": menuRight,
+	"\n":       menuSelect,
+	"\r":       menuSelect,
+	" ":        menuSelect,
+}
+
+// menuItem represents a single line of a menu
+// an item with values is a setting which can be cycled through, otherwise the item is an action
+type menuItem struct {
+	label    string
+	values   []string
+	selected int
+}
+
+func (m *menuItem) value() string {
+	if len(m.values) == 0 {
+		return ""
+	}
+	return m.values[m.selected]
+}
+
+// setValue selects the specified value, leaving the selection unchanged if not found
+func (m *menuItem) setValue(value string) {
+	for i := range m.values {
+		if m.values[i] == value {
+			m.selected = i
+			return
+		}
+	}
+}
+
+func (m *menuItem) cycle(step int) {
+	if len(m.values) == 0 {
+		return
+	}
+	m.selected = (m.selected + step + len(m.values)) % len(m.values)
+}
+
+func (m *menuItem) String() string {
+	if len(m.values) == 0 {
+		return m.label
+	}
+	return fmt.Sprintf("%s: < %s >", m.label, m.value())
+}
+
+// menu is a keyboard driven list of items rendered to the terminal
+// if a preview is provided it is displayed to the right of the items and updated as they change
+type menu struct {
+	title   string
+	header  string
+	items   []*menuItem
+	cursor  int
+	color   canvas.Color
+	style   canvas.BoxStyle
+	preview func() [][]canvas.Cell
+}
+
+func (m *menu) cells() [][]canvas.Cell {
+	lines := []string{}
+	if m.header != "" {
+		lines = append(lines, strings.Split(m.header, "\n")...)
+		lines = append(lines, "")
+	}
+
+	for i, item := range m.items {
+		if i == m.cursor {
+			lines = append(lines, "> "+item.String())
+			continue
+		}
+		lines = append(lines, "  "+item.String())
+	}
+
+	menuCells := canvas.StyledBox(canvas.CellsFromString(strings.Join(lines, "\n"), m.color), m.title, m.style)
+	if m.preview == nil {
+		return menuCells
+	}
+	return layout.HStack(layout.Start, menuCells, m.preview())
+}
+
+func (m *menu) handleInput(input menuInput) (selected bool) {
+	switch input {
+	case menuUp:
+		m.cursor = (m.cursor - 1 + len(m.items)) % len(m.items)
+	case menuDown:
+		m.cursor = (m.cursor + 1) % len(m.items)
+	case menuLeft:
+		m.items[m.cursor].cycle(-1)
+	case menuRight:
+		m.items[m.cursor].cycle(1)
+	case menuSelect:
+		if len(m.items[m.cursor].values) != 0 {
+			m.items[m.cursor].cycle(1)
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// run displays the menu until an action item is selected
+func (m *menu) run(t *terminal) (*menuItem, error) {
+	c := t.canvas
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	input, readErr := t.reader.ReadInput(ctx)
+
+	var prevHeight, prevWidth int
+	for {
+		cells := m.cells()
+		// clear anything left over from a larger frame
+		if len(cells) != prevHeight || layout.Width(cells) != prevWidth {
+			if err := c.Init(); err != nil {
+				return nil, err
+			}
+			prevHeight, prevWidth = len(cells), layout.Width(cells)
+		}
+
+		c.UpdateCells(cells)
+		if err := c.Render(); err != nil {
+			return nil, err
+		}
+
+		select {
+		case err := <-readErr:
+			return nil, err
+		case sig := <-t.sigs:
+			return nil, signalError{sig: sig}
+		case <-t.resize:
+			// the terminal may have re-wrapped the previous frame, so force it to be cleared
+			prevHeight, prevWidth = 0, 0
+		case in := <-input:
+			translated, ok := menuControls[string(in)]
+			if !ok {
+				continue
+			}
+			if m.handleInput(translated) {
+				return m.items[m.cursor], nil
+			}
+		}
+	}
+}
+
+// signalError is returned when a signal is received while waiting on the user
+type signalError struct {
+	sig os.Signal
+}
+
+func (s signalError) Error() string {
+	return fmt.Sprintf("received signal: %s", s.sig)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ShawnROGrady/gotris/internal/highscore"
+)
+
+func printScores(table *highscore.Table) {
+	keys := table.Keys()
+	if len(keys) == 0 {
+		fmt.Println("No high scores yet")
+		return
+	}
+
+	for _, key := range keys {
+		fmt.Printf("%s:\n", key)
+		for i, entry := range table.Entries[key] {
+			value := fmt.Sprintf("%d", entry.Score)
+			if entry.Time != 0 {
+				value = formatTime(entry.Time)
+			}
+			fmt.Printf("%3d. %-3s %8s  %s\n", i+1, entry.Initials, value, entry.Date.Format("2006-01-02"))
+		}
+		fmt.Println()
+	}
+}
+
+// entryResult describes what the entry is ranked by
+func entryResult(entry highscore.Entry) string {
+	if entry.Time != 0 {
+		return fmt.Sprintf("Time: %s", formatTime(entry.Time))
+	}
+	return fmt.Sprintf("Score: %d", entry.Score)
+}
+
+// formatTime formats a time in minutes, seconds and hundredths of a second (e.g. 1:05.32)
+func formatTime(d time.Duration) string {
+	hundredths := int(d / (10 * time.Millisecond))
+	return fmt.Sprintf("%d:%02d.%02d", hundredths/6000, hundredths/100%60, hundredths%100)
+}
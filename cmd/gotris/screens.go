@@ -0,0 +1,442 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/ShawnROGrady/gotris/internal/canvas"
+	"github.com/ShawnROGrady/gotris/internal/config"
+	"github.com/ShawnROGrady/gotris/internal/game"
+	"github.com/ShawnROGrady/gotris/internal/highscore"
+	"github.com/ShawnROGrady/gotris/internal/inputreader"
+	"github.com/ShawnROGrady/gotris/internal/theme"
+)
+
+// the actions available after a game ends
+const (
+	playAgainItem      = "Play again"
+	changeSettingsItem = "Change settings"
+	quitItem           = "Quit"
+)
+
+const (
+	toggleOn  = "on"
+	toggleOff = "off"
+)
+
+func toggleValue(on bool) string {
+	if on {
+		return toggleOn
+	}
+	return toggleOff
+}
+
+// terminal holds everything needed to interact with the user across multiple games
+// this way the terminal only needs to be set up once
+type terminal struct {
+	reader     inputreader.InputReader
+	writer     io.Writer
+	canvas     canvas.Canvas
+	sigs       <-chan os.Signal
+	resize     <-chan os.Signal
+	size       func() (width, height int)
+	showMenu   bool
+	scores     *highscore.Table
+	scoresPath string
+}
+
+// roundResult is the outcome of a single game
+type roundResult struct {
+	game.Result
+	rank int // the position in the high score table, 0 if the score wasn't added
+}
+
+// play runs a single game with the specified settings until it ends
+func (t *terminal) play(s settings) (roundResult, error) {
+	opts, err := s.options()
+	if err != nil {
+		return roundResult{}, err
+	}
+	// all games share the same reader, so the terminal is only ever read from by one goroutine
+	// and are drawn on the alternate screen the terminal's canvas has already switched to
+	opts = append(opts, game.WithInputReader(t.reader), game.WithoutAltScreen())
+
+	if t.scores != nil {
+		// the side bar only shows the best score, which isn't what sprints are ranked by
+		if best, ok := t.scores.Best(highscore.Key(s.Mode, s.Difficulty)); ok && ranking(s.Mode) == highscore.ByScore {
+			opts = append(opts, game.WithHighScore(best.Score))
+		}
+	}
+
+	if t.size != nil {
+		opts = append(opts, game.WithTerminalSize(t.size()))
+	}
+
+	g := game.New(nil, t.writer, opts...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the game runs in the background so signals and resizes can still be handled
+	// panics are returned as errors (Run also recovers from those in the game's own goroutines), so the terminal can be restored
+	type outcome struct {
+		result game.Result
+		err    error
+	}
+	finished := make(chan outcome, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				finished <- outcome{err: game.PanicError{Value: r, Stack: debug.Stack()}}
+			}
+		}()
+		result, err := g.Run(ctx)
+		finished <- outcome{result: result, err: err}
+	}()
+
+	for {
+		select {
+		case o := <-finished:
+			if o.err != nil {
+				return roundResult{}, o.err
+			}
+			return roundResult{Result: o.result}, nil
+		case sig := <-t.sigs:
+			// wait for the game to stop, so nothing is rendered after the terminal is restored
+			cancel()
+			<-finished
+			return roundResult{}, signalError{sig: sig}
+		case <-t.resize:
+			if err := g.Resize(t.size()); err != nil {
+				return roundResult{}, err
+			}
+		}
+	}
+}
+
+// session plays games until the user decides to quit, returning the result of the final game
+func (t *terminal) session(s settings) (roundResult, error) {
+	var result roundResult
+	if t.showMenu {
+		start, err := t.mainMenu(&s)
+		if err != nil || !start {
+			return result, err
+		}
+	}
+
+	for {
+		result, err := t.play(s)
+		if err != nil {
+			return result, err
+		}
+
+		if err := t.recordScore(s, &result); err != nil {
+			return result, err
+		}
+
+		choice, err := t.gameOverScreen(s, result)
+		if err != nil {
+			return result, err
+		}
+
+		switch choice {
+		case changeSettingsItem:
+			start, err := t.mainMenu(&s)
+			if err != nil || !start {
+				return result, err
+			}
+		case quitItem:
+			return result, nil
+		}
+	}
+}
+
+// ranking returns how the results of the mode are ranked in the high score table
+// sprints end once the line goal is reached, so they're ranked by the fastest time rather than the score
+func ranking(mode string) highscore.Ranking {
+	if mode == game.SprintMode {
+		return highscore.ByTime
+	}
+	return highscore.ByScore
+}
+
+// recordScore adds the result to the high score table if it qualifies
+func (t *terminal) recordScore(s settings, result *roundResult) error {
+	var (
+		key      = highscore.Key(s.Mode, s.Difficulty)
+		rankedBy = ranking(s.Mode)
+		entry    = highscore.Entry{Score: result.Score, Date: time.Now()}
+	)
+	if rankedBy == highscore.ByTime {
+		if result.Reason != game.GoalReached {
+			// only completed sprints have a time
+			return nil
+		}
+		entry.Time = result.Duration
+	}
+	if t.scores == nil || !t.scores.Qualifies(key, rankedBy, entry) {
+		return nil
+	}
+
+	initials, err := t.initialsScreen(s, entry)
+	if err != nil {
+		return err
+	}
+	entry.Initials = initials
+
+	rank, err := t.scores.Add(key, rankedBy, entry)
+	if err != nil {
+		return err
+	}
+	result.rank = rank
+
+	return t.scores.Save(t.scoresPath)
+}
+
+const maxInitials = 3
+
+// initialsScreen prompts the user to enter their initials for a new high score
+func (t *terminal) initialsScreen(s settings, entry highscore.Entry) (string, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	input, readErr := t.reader.ReadInput(ctx)
+
+	if err := t.canvas.Init(); err != nil {
+		return "", err
+	}
+
+	initials := []byte{}
+	for {
+		var (
+			entered = string(initials) + strings.Repeat("_", maxInitials-len(initials))
+			text    = fmt.Sprintf("%s\n\nEnter your initials: %s\n\nPress ENTER to confirm", entryResult(entry), entered)
+		)
+		t.canvas.UpdateCells(canvas.StyledBox(canvas.CellsFromString(text, s.TextColor()), "NEW HIGH SCORE", s.BoxStyle()))
+		if err := t.canvas.Render(); err != nil {
+			return "", err
+		}
+
+		select {
+		case err := <-readErr:
+			return "", err
+		case sig := <-t.sigs:
+			return "", signalError{sig: sig}
+		case <-t.resize:
+			// the terminal may have re-wrapped the previous frame, so clear it before re-rendering
+			if err := t.canvas.Init(); err != nil {
+				return "", err
+			}
+		case in := <-input:
+			if len(in) == 0 || in[0] == '\u001b' {
+				// ignore escape sequences (e.g. arrow keys)
+				continue
+			}
+			for _, b := range in {
+				switch {
+				case (b == '\n' || b == '\r') && len(initials) != 0:
+					return string(initials), nil
+				case (b == '\u007f' || b == '\b') && len(initials) != 0:
+					initials = initials[:len(initials)-1]
+				case len(initials) < maxInitials && isAlphanumeric(b):
+					initials = append(initials, bytes.ToUpper([]byte{b})...)
+				}
+			}
+		}
+	}
+}
+
+func isAlphanumeric(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+// gameOverScreen displays the result of a game and asks the user what to do next
+func (t *terminal) gameOverScreen(s settings, result roundResult) (string, error) {
+	header := fmt.Sprintf("Reason: %s\nScore: %d\nLines: %d\nPieces: %d (%.2f/s)", result.Reason, result.Score, result.Lines, result.Stats.Pieces, result.Stats.PiecesPerSecond())
+	if result.rank != 0 {
+		header = fmt.Sprintf("%s\nNew high score! (#%d)", header, result.rank)
+	}
+
+	m := &menu{
+		title:  "GAME OVER",
+		header: header,
+		items: []*menuItem{
+			{label: playAgainItem},
+			{label: changeSettingsItem},
+			{label: quitItem},
+		},
+		color: s.TextColor(),
+		style: s.BoxStyle(),
+	}
+
+	selected, err := m.run(t)
+	if err != nil {
+		return "", err
+	}
+	return selected.label, nil
+}
+
+// the actions available from the main menu
+const (
+	startItem = "Start"
+)
+
+// boardSize is a preset board size selectable from the main menu
+type boardSize struct {
+	name   string
+	width  int
+	height int
+}
+
+func (b boardSize) String() string {
+	return fmt.Sprintf("%s (%dx%d)", b.name, b.width, b.height)
+}
+
+var boardSizes = []boardSize{
+	{name: "small", width: 8, height: 16},
+	{name: "standard", width: 10, height: 20},
+	{name: "large", width: 12, height: 24},
+}
+
+// mainMenu allows the user to update the settings before starting a game
+// returns false if the user chose to quit instead
+func (t *terminal) mainMenu(s *settings) (bool, error) {
+	var (
+		mode           = &menuItem{label: "Mode", values: modes()}
+		difficulty     = &menuItem{label: "Difficulty", values: difficulties()}
+		scheme         = &menuItem{label: "Controls", values: schemeNames(s.Config)}
+		colors         = &menuItem{label: "Theme", values: menuThemeNames(s.Config)}
+		sizes, current = menuBoardSizes(s.BoardSize())
+		size           = &menuItem{label: "Board size", values: boardSizeNames(sizes)}
+		ghost          = &menuItem{label: "Ghost", values: []string{toggleOn, toggleOff}}
+		side           = &menuItem{label: "Side bar", values: []string{toggleOn, toggleOff}}
+	)
+	mode.setValue(s.Mode)
+	scheme.setValue(strings.Join(s.Schemes, ", "))
+	difficulty.setValue(s.Difficulty)
+	colors.setValue(currentThemeName(s.Config))
+	size.setValue(current.String())
+	ghost.setValue(toggleValue(!s.DisableGhost))
+	side.setValue(toggleValue(!s.DisableSide))
+
+	// apply copies the currently selected values to the provided settings
+	apply := func(s *settings) {
+		s.Mode = mode.value()
+		s.Difficulty = difficulty.value()
+		if _, err := s.SchemeFromName(scheme.value()); err == nil {
+			s.Schemes = []string{scheme.value()}
+		}
+		s.Theme = colors.value()
+		for _, b := range sizes {
+			if b.String() == size.value() {
+				s.Width, s.Height = b.width, b.height
+			}
+		}
+		s.DisableGhost = ghost.value() == toggleOff
+		s.DisableSide = side.value() == toggleOff
+	}
+
+	m := &menu{
+		title: "GOTRIS",
+		items: []*menuItem{
+			{label: startItem},
+			mode,
+			difficulty,
+			scheme,
+			colors,
+			size,
+			ghost,
+			side,
+			{label: quitItem},
+		},
+		color: s.TextColor(),
+		style: s.BoxStyle(),
+		preview: func() [][]canvas.Cell {
+			preview := *s
+			apply(&preview)
+			opts, err := preview.options()
+			if err != nil {
+				return nil
+			}
+			return game.New(nil, ioutil.Discard, opts...).PotentialColorCells()
+		},
+	}
+
+	selected, err := m.run(t)
+	if err != nil {
+		return false, err
+	}
+	if selected.label == quitItem {
+		return false, nil
+	}
+
+	apply(s)
+	return true, nil
+}
+
+// schemeNames lists the names of the available schemes, including any custom schemes
+// if the current scheme is a combination of schemes it is listed first
+func schemeNames(c config.Config) []string {
+	names := []string{}
+	if len(c.Schemes) > 1 {
+		names = append(names, strings.Join(c.Schemes, ", "))
+	}
+	available, err := c.AvailableSchemes()
+	if err != nil {
+		// invalid custom schemes are reported when the options are validated
+		available = game.AvailableSchemes()
+	}
+	for _, s := range available {
+		names = append(names, s.String())
+	}
+	return names
+}
+
+func boardSizeNames(sizes []boardSize) []string {
+	names := []string{}
+	for _, b := range sizes {
+		names = append(names, b.String())
+	}
+	return names
+}
+
+// menuBoardSizes lists the preset board sizes, along with the current size
+// if the current size isn't a preset it's listed first as a custom size
+func menuBoardSizes(width, height int) ([]boardSize, boardSize) {
+	for _, b := range boardSizes {
+		if b.width == width && b.height == height {
+			return boardSizes, b
+		}
+	}
+	current := boardSize{name: "custom", width: width, height: height}
+	return append([]boardSize{current}, boardSizes...), current
+}
+
+// menuThemeNames lists the names of the built in themes
+// if the current theme is loaded from a file its path is listed first
+func menuThemeNames(c config.Config) []string {
+	names := []string{}
+	if _, err := theme.FromName(c.Theme); c.Theme != "" && err != nil {
+		names = append(names, c.Theme)
+	}
+	return append(names, themeNames()...)
+}
+
+// currentThemeName is the name of the theme as listed in the menu
+func currentThemeName(c config.Config) string {
+	if c.Theme != "" {
+		return c.Theme
+	}
+	selected, err := c.SelectedTheme()
+	if err != nil {
+		return theme.ClassicName
+	}
+	return selected.Name
+}
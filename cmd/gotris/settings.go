@@ -0,0 +1,69 @@
+package main
+
+import (
+	"github.com/ShawnROGrady/gotris/internal/canvas"
+	"github.com/ShawnROGrady/gotris/internal/config"
+	"github.com/ShawnROGrady/gotris/internal/game"
+	"github.com/ShawnROGrady/gotris/internal/theme"
+)
+
+// settings represents the user configurable options for a game
+type settings struct {
+	config.Config
+	debugMode    bool
+	seed         int64 // the seed used by every game, 0 for a random seed per game
+	colorProfile canvas.ColorProfile
+}
+
+// options converts the settings to the options used to create a new game
+func (s settings) options() ([]game.Option, error) {
+	opts, err := s.Config.Options()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.debugMode {
+		opts = append(opts, game.WithDebugMode())
+	}
+	if s.seed != 0 {
+		opts = append(opts, game.WithSeed(s.seed))
+	}
+	opts = append(opts, game.WithColorProfile(s.colorProfile))
+	return opts, nil
+}
+
+func difficulties() []string {
+	return []string{game.BeginnerDifficulty, game.NoviceDifficulty, game.ProDifficulty, game.ExpertDifficulty}
+}
+
+func themeNames() []string {
+	names := []string{}
+	for _, t := range theme.Available() {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+func panelNames() []string {
+	names := []string{}
+	for _, p := range game.Panels() {
+		names = append(names, p.String())
+	}
+	return names
+}
+
+func ghostStyleNames() []string {
+	names := []string{}
+	for _, g := range canvas.GhostStyles() {
+		names = append(names, g.String())
+	}
+	return names
+}
+
+func modes() []string {
+	names := []string{}
+	for _, m := range game.AvailableModes() {
+		names = append(names, m.String())
+	}
+	return names
+}
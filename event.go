@@ -0,0 +1,91 @@
+package gotris
+
+import (
+	"time"
+
+	"github.com/ShawnROGrady/gotris/internal/game"
+)
+
+// EventType identifies what happened in an event
+type EventType int
+
+// the types of event
+const (
+	// PieceLocked means the current piece was locked in place, Shape is the shape of the piece
+	PieceLocked EventType = iota
+	// LinesCleared means full rows were cleared, Lines is the number of rows
+	LinesCleared
+	// LevelUp means the level increased, Level is the new level
+	LevelUp
+	// PieceSpawned means a new current piece was spawned, Shape is the shape of the piece
+	PieceSpawned
+	// GameOver means the game ended, Reason is why
+	GameOver
+)
+
+func (t EventType) String() string {
+	typeNames := map[EventType]string{
+		PieceLocked:  "piece locked",
+		LinesCleared: "lines cleared",
+		LevelUp:      "level up",
+		PieceSpawned: "piece spawned",
+		GameOver:     "game over",
+	}
+
+	return typeNames[t]
+}
+
+var internalEventTypes = map[game.EventType]EventType{
+	game.PieceLocked:  PieceLocked,
+	game.LinesCleared: LinesCleared,
+	game.LevelUp:      LevelUp,
+	game.PieceSpawned: PieceSpawned,
+	game.GameOver:     GameOver,
+}
+
+// Event is something which happened as a result of an action or the game advancing
+// only the fields relevant to the type of event are set
+type Event struct {
+	Type   EventType
+	Time   time.Duration // the game time at which the event happened, see Game.Advance
+	Shape  Shape
+	Lines  int
+	Level  int
+	Reason EndReason
+}
+
+func newEvent(event game.Event) Event {
+	e := Event{
+		Type:   internalEventTypes[event.Type],
+		Time:   event.Time,
+		Lines:  event.Lines,
+		Level:  event.Level,
+		Reason: internalReasons[event.Reason],
+	}
+	if event.Type == game.PieceLocked || event.Type == game.PieceSpawned {
+		e.Shape = shapeOf(event.Piece)
+	}
+	return e
+}
+
+// Subscribe registers a handler which is called with every event as it happens, in addition to the events being returned
+// handlers shouldn't call back into the game
+func (g *Game) Subscribe(handler func(Event)) {
+	g.subscribers = append(g.subscribers, handler)
+}
+
+// record is subscribed to the events of the engine
+func (g *Game) record(event game.Event) {
+	e := newEvent(event)
+	g.events = append(g.events, e)
+	for _, handler := range g.subscribers {
+		handler(e)
+	}
+}
+
+// collect applies a change to the game, returning the events which happened as a result
+func (g *Game) collect(change func()) []Event {
+	g.events = []Event{}
+	change()
+	return g.events
+}
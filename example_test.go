@@ -0,0 +1,59 @@
+package gotris_test
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ShawnROGrady/gotris"
+)
+
+// A very simple bot, which hard drops every piece until the game ends
+func Example() {
+	g, err := gotris.New(gotris.WithMode(gotris.Sprint))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for !g.State().Over() {
+		for _, event := range g.Apply(gotris.HardDrop) {
+			if event.Type == gotris.LinesCleared {
+				fmt.Printf("cleared %d lines\n", event.Lines)
+			}
+		}
+	}
+
+	result := g.Result()
+	fmt.Println(result.Stats.Pieces > 0)
+	// Output: true
+}
+
+func ExampleGame_Apply() {
+	g, err := gotris.New()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, event := range g.Apply(gotris.HardDrop) {
+		fmt.Println(event.Type)
+	}
+	// Output:
+	// piece locked
+	// piece spawned
+}
+
+func ExampleGame_State() {
+	g, err := gotris.New(gotris.WithBoardSize(8, 16))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	state := g.State()
+	fmt.Printf("%dx%d board, %d blocks in the current piece, %d pieces queued\n", state.Board.Width, state.Board.Height, len(state.Current.Blocks), len(state.Next))
+	// Output: 8x16 board, 4 blocks in the current piece, 6 pieces queued
+}
+
+func ExampleWithBoardSize() {
+	_, err := gotris.New(gotris.WithBoardSize(2, 20))
+	fmt.Println(err)
+	// Output: invalid board size: 2x20 (the minimum is 4x4)
+}
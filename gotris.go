@@ -0,0 +1,89 @@
+// Package gotris allows the game engine behind gotris to be embedded in other programs, e.g. bots, servers and tests
+//
+// A Game is headless: it doesn't display anything or read any input, instead actions are applied and time is advanced by
+// the caller, which can inspect the resulting state and events. The types in this package are independent of the internal
+// packages used to implement the game, so they can remain stable as the implementation changes.
+package gotris
+
+import (
+	"time"
+
+	"github.com/ShawnROGrady/gotris/internal/game"
+)
+
+// Game is a single game of tetris
+// a game isn't safe for concurrent use
+type Game struct {
+	engine      *game.Engine
+	events      []Event
+	subscribers []func(Event)
+}
+
+// New returns a new game with the specified options, ready for actions and ticks to be applied
+func New(opts ...Option) (*Game, error) {
+	internalOpts := []game.Option{}
+	for _, opt := range opts {
+		if opt.err != nil {
+			return nil, opt.err
+		}
+		internalOpts = append(internalOpts, opt.options...)
+	}
+
+	g := &Game{engine: game.NewEngine(internalOpts...)}
+	g.engine.Start()
+	// subscribed once started, so the first piece spawning isn't reported by the first action
+	g.engine.Subscribe(g.record)
+	return g, nil
+}
+
+// Apply performs the action on the current piece, returning the events which happened as a result
+// nothing happens once the game is over
+func (g *Game) Apply(action Action) []Event {
+	internal, ok := internalActions[action]
+	if !ok {
+		return nil
+	}
+	return g.collect(func() { g.engine.Apply(internal) })
+}
+
+// Advance moves the game forward in time, returning the events which happened as a result
+// gravity is applied once every GravityInterval, and the game ends once the time limit of its mode is reached
+// no time passes unless the game is advanced, so a game can be simulated faster than real time or replayed exactly
+func (g *Game) Advance(d time.Duration) []Event {
+	return g.collect(func() { g.engine.Advance(d) })
+}
+
+// Tick applies gravity to the current piece once, returning the events which happened as a result
+// it can be used instead of Advance when the time doesn't matter (e.g. by a bot)
+func (g *Game) Tick() []Event {
+	return g.collect(func() { g.engine.Tick() })
+}
+
+// TimeUp ends the game if its mode has a time limit, returning the events which happened as a result
+// it can be used instead of Advance to end the game early
+func (g *Game) TimeUp() []Event {
+	if g.TimeLimit() == 0 {
+		return nil
+	}
+	return g.collect(func() { g.engine.End(game.TimeUp) })
+}
+
+// GravityInterval returns how long gravity takes to move the current piece down at the current level
+func (g *Game) GravityInterval() time.Duration {
+	return g.engine.GravityInterval()
+}
+
+// TimeLimit returns the time limit of the game's mode, 0 if there isn't one
+func (g *Game) TimeLimit() time.Duration {
+	return g.engine.TimeLimit()
+}
+
+// State returns a snapshot of the game
+func (g *Game) State() State {
+	return newState(g.engine)
+}
+
+// Result returns the result of the game so far, or of the whole game once it's over
+func (g *Game) Result() Result {
+	return newResult(g.engine.Result())
+}
@@ -0,0 +1,201 @@
+package gotris
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ShawnROGrady/gotris/internal/game"
+)
+
+var newTests = map[string]struct {
+	options   []Option
+	expectErr bool
+}{
+	"default": {},
+	"all options": {
+		options: []Option{WithMode(Ultra), WithBoardSize(12, 24), WithHiddenRows(2), WithInitialLevel(9), WithPartialLockOut(), WithSeed(7)},
+	},
+	"unknown mode": {
+		options:   []Option{WithMode("endless")},
+		expectErr: true,
+	},
+	"board too small": {
+		options:   []Option{WithBoardSize(3, 20)},
+		expectErr: true,
+	},
+	"negative hidden rows": {
+		options:   []Option{WithHiddenRows(-1)},
+		expectErr: true,
+	},
+	"negative level": {
+		options:   []Option{WithInitialLevel(-1)},
+		expectErr: true,
+	},
+}
+
+func TestNew(t *testing.T) {
+	for testName, test := range newTests {
+		g, err := New(test.options...)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("Unexpectedly no error for test case '%s'", testName)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for test case '%s': %s", testName, err)
+			continue
+		}
+		if state := g.State(); state.Over() || len(state.Current.Blocks) != 4 {
+			t.Errorf("Unexpected initial state for test case '%s' [state = %+v]", testName, state)
+		}
+	}
+}
+
+func TestHardDrop(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var (
+		before = g.State()
+		events = g.Apply(HardDrop)
+		after  = g.State()
+	)
+
+	if len(events) != 2 || events[0].Type != PieceLocked || events[1].Type != PieceSpawned {
+		t.Fatalf("Unexpected events [events = %+v]", events)
+	}
+	if events[0].Shape != before.Current.Shape || events[1].Shape != after.Current.Shape || after.Current.Shape != before.Next[0] {
+		t.Errorf("Unexpected shapes [events = %+v, before = %s, after = %s, next = %s]", events, before.Current.Shape, after.Current.Shape, before.Next[0])
+	}
+
+	// the dropped piece is where the ghost was, while the new piece isn't part of the board
+	for _, p := range before.Ghost.Blocks {
+		if shape := after.Board.At(p); shape != before.Current.Shape {
+			t.Errorf("Unexpected block at %+v [expected = %s, actual = %s]", p, before.Current.Shape, shape)
+		}
+	}
+	for _, p := range after.Current.Blocks {
+		if shape := after.Board.At(p); shape != Empty {
+			t.Errorf("Unexpected block at %+v of the current piece [expected = empty, actual = %s]", p, shape)
+		}
+	}
+	if result := g.Result(); result.Stats.Pieces != 1 || result.Stats.PieceCounts[before.Current.Shape] != 1 {
+		t.Errorf("Unexpected stats [stats = %+v]", result.Stats)
+	}
+}
+
+func TestSeed(t *testing.T) {
+	// shapes plays a game by hard dropping every piece, returning the shape of each piece spawned
+	shapes := func(seed int64) ([]Shape, Result) {
+		g, err := New(WithSeed(seed))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		shapes := []Shape{g.State().Current.Shape}
+		for !g.State().Over() {
+			for _, event := range g.Apply(HardDrop) {
+				if event.Type == PieceSpawned {
+					shapes = append(shapes, event.Shape)
+				}
+			}
+		}
+		return shapes, g.Result()
+	}
+
+	first, result := shapes(1234)
+	if result.Seed != 1234 {
+		t.Errorf("Unexpected seed in result [expected = %d, actual = %d]", 1234, result.Seed)
+	}
+
+	// replaying with the seed from the result spawns the same pieces
+	replay, replayResult := shapes(result.Seed)
+	if !reflect.DeepEqual(replay, first) {
+		t.Errorf("Unexpected pieces when replaying [expected = %v, actual = %v]", first, replay)
+	}
+	if replayResult.Score != result.Score || replayResult.Stats.Pieces != result.Stats.Pieces {
+		t.Errorf("Unexpected result when replaying [expected = %+v, actual = %+v]", result, replayResult)
+	}
+}
+
+func TestTimeUp(t *testing.T) {
+	marathon, err := New()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if events := marathon.TimeUp(); len(events) != 0 || marathon.State().Over() {
+		t.Errorf("Unexpected time up for a game without a time limit [events = %+v]", events)
+	}
+
+	ultra, err := New(WithMode(Ultra))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	events := ultra.TimeUp()
+	if len(events) != 1 || events[0].Type != GameOver || events[0].Reason != TimeUp {
+		t.Errorf("Unexpected events when the time limit is reached [events = %+v]", events)
+	}
+	if events := ultra.Apply(HardDrop); len(events) != 0 {
+		t.Errorf("Unexpected events after the game ended [events = %+v]", events)
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	g, err := New(WithMode(Ultra))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// gravity moves the current piece down one row per interval
+	before := g.State()
+	if events := g.Advance(g.GravityInterval()); len(events) != 0 {
+		t.Errorf("Unexpected events after a single interval [events = %+v]", events)
+	}
+	after := g.State()
+	for i, p := range before.Current.Blocks {
+		if after.Current.Blocks[i] != (Point{X: p.X, Y: p.Y - 1}) {
+			t.Errorf("Unexpected position of block %d [before = %+v, after = %+v]", i, p, after.Current.Blocks[i])
+		}
+	}
+
+	// the pieces which fall in the meantime stack up in the middle, but not high enough to top out
+	var last Event
+	for !g.State().Over() {
+		events := g.Advance(time.Second)
+		if len(events) != 0 {
+			last = events[len(events)-1]
+		}
+	}
+	if last.Type != GameOver || last.Reason != TimeUp || last.Time != g.TimeLimit() {
+		t.Errorf("Unexpected last event [expected = %s, actual = %+v]", GameOver, last)
+	}
+	if result := g.Result(); result.Duration != g.TimeLimit() {
+		t.Errorf("Unexpected duration [expected = %s, actual = %s]", g.TimeLimit(), result.Duration)
+	}
+}
+
+func TestEndReasons(t *testing.T) {
+	// every reason should have a public equivalent with the same description
+	for _, internal := range []game.EndReason{game.NotOver, game.BlockOut, game.LockOut, game.PartialLockOut, game.GoalReached, game.TimeUp} {
+		reason, ok := internalReasons[internal]
+		if !ok {
+			t.Errorf("Missing end reason '%s'", internal)
+		} else if reason.String() != internal.String() {
+			t.Errorf("Unexpected end reason description [expected = %s, actual = %s]", internal, reason)
+		}
+	}
+}
+
+func TestShapes(t *testing.T) {
+	for _, shape := range Shapes() {
+		if shapeFromLabel(shape.String()) != shape {
+			t.Errorf("Unexpected shape from label '%s' [expected = %d, actual = %d]", shape, shape, shapeFromLabel(shape.String()))
+		}
+	}
+	if Empty.String() != "" {
+		t.Errorf("Unexpected name of the empty shape: '%s'", Empty)
+	}
+}
@@ -3,7 +3,6 @@ package canvas
 import (
 	"bytes"
 	"io"
-	"runtime"
 	"strconv"
 )
 
@@ -19,6 +18,7 @@ type Canvas interface {
 	Init() error
 	Render() error
 	UpdateCells(newCells [][]Cell)
+	Close() error
 }
 
 // TermCanvas represents what is actually rendered to the user via the terminal
@@ -27,9 +27,14 @@ type TermCanvas struct {
 	background Color
 	cells      [][]Cell
 	debugMode  bool
+	altScreen  bool
+	inAlt      bool // whether the canvas has switched to the alternate screen
+	profile    ColorProfile
 	width      int
 	height     int
 	cellCaches []map[string]string
+	prev       [][]renderedCell // the previously rendered frame, nil if the screen was cleared
+	sgrCache   map[sgrTransition]sgrState
 }
 
 // New returns a new canvas
@@ -44,9 +49,12 @@ func New(term io.Writer, opts ...Option) *TermCanvas {
 	t := &TermCanvas{
 		dest:       term,
 		background: DefaultBackground,
+		altScreen:  true,
+		profile:    TrueColor,
 		width:      DefaultWidth,
 		height:     DefaultHeight,
 		cellCaches: cellCaches,
+		sgrCache:   make(map[sgrTransition]sgrState),
 	}
 
 	for i := range opts {
@@ -63,44 +71,191 @@ func New(term io.Writer, opts ...Option) *TermCanvas {
 }
 
 // Init sets up the canvas in order to be written to
-// for now this just clears the entire screen
+// unless disabled (or in debug mode) this switches to the alternate screen and hides the cursor, then clears the screen
+// the canvas can be initialized again to clear the screen, which only switches to the alternate screen if it was closed
 func (c *TermCanvas) Init() error {
+	if c.usesAltScreen() && !c.inAlt {
+		if _, err := c.dest.Write(enterAltScreen); err != nil {
+			return err
+		}
+		c.inAlt = true
+	}
 	return c.clear()
 }
 
+// Close restores the terminal to its state before the canvas was initialized
+// this leaves the alternate screen, so anything written to the terminal beforehand is visible again
+func (c *TermCanvas) Close() error {
+	if !c.inAlt {
+		return nil
+	}
+	if _, err := c.dest.Write(exitAltScreen); err != nil {
+		return err
+	}
+	c.inAlt = false
+	return nil
+}
+
+// debug mode renders each frame after the previous one, so the alternate screen would hide the output on exit
+func (c *TermCanvas) usesAltScreen() bool {
+	return c.altScreen && !c.debugMode
+}
+
 // Render renders the current canvas
+// after the first frame only the cells which changed since the previous frame are written
 func (c *TermCanvas) Render() error {
-	var b bytes.Buffer
+	var (
+		b     bytes.Buffer
+		frame = c.frame()
+	)
+	if c.debugMode || !sameShape(c.prev, frame) {
+		c.writeFrame(&b, frame)
+	} else {
+		c.writeChanges(&b, frame)
+	}
+	if !c.debugMode {
+		// debug mode renders each frame after the previous one, so every cell is always written
+		c.prev = frame
+	}
+
+	_, err := c.dest.Write(b.Bytes())
+	return err
+}
+
+// renderedCell is what was written for a single cell
+// state is the graphic rendition left by the previous cells, drawn is the rendition after applying the cell's own formatting
+// the cell appears the same if both its text and drawn rendition are unchanged
+type renderedCell struct {
+	state sgrState
+	drawn sgrState
+	text  string
+}
+
+func (r renderedCell) looksLike(other renderedCell) bool {
+	return r.text == other.text && r.drawn == other.drawn
+}
+
+// frame converts the current cells to what will be written to the terminal
+func (c *TermCanvas) frame() [][]renderedCell {
+	frame := make([][]renderedCell, len(c.cells))
+	for i, row := range c.cells {
+		var state sgrState
+		frame[i] = make([]renderedCell, len(row))
+		for j, cell := range row {
+			text := c.cellString(cell)
+			drawn := c.drawnState(state, text)
+			frame[i][j] = renderedCell{state: state, drawn: drawn, text: text}
+			state = drawn
+		}
+	}
+	return frame
+}
+
+// drawnState caches the result of applying the formatting of each cell, since most frames reuse the same cells
+func (c *TermCanvas) drawnState(state sgrState, text string) sgrState {
+	key := sgrTransition{state: state, text: text}
+	if drawn, ok := c.sgrCache[key]; ok {
+		return drawn
+	}
+	drawn := state.apply(text)
+	c.sgrCache[key] = drawn
+	return drawn
+}
+
+type sgrTransition struct {
+	state sgrState
+	text  string
+}
+
+func (c *TermCanvas) cellString(cell Cell) string {
+	if cell == nil {
+		if c.profile == Monochrome {
+			return plainEmptyBlock
+		}
+		return c.background.downgrade(c.profile).String() + block
+	}
+	cellType, cellHash := cell.hash()
+	if cellString, ok := c.cellCaches[cellType][cellHash]; ok {
+		return cellString
+	}
+	cellString := cell.render(c.profile)
+	c.cellCaches[cellType][cellHash] = cellString
+	return cellString
+}
+
+// writeFrame writes every cell of the frame
+func (c *TermCanvas) writeFrame(b *bytes.Buffer, frame [][]renderedCell) {
 	if !c.debugMode {
 		// reset cursor
 		b.Write(resetTermCursor)
 	}
 
-	for _, row := range c.cells {
+	for _, row := range frame {
 		for _, cell := range row {
-			if cell == nil {
-				b.WriteString(c.background.String())
-				b.WriteString(block)
+			b.WriteString(cell.text)
+		}
+		b.WriteByte('\n')
+		b.Write(c.resetFormatting())
+	}
+	// clear any potential formatting
+	b.Write(c.resetFormatting())
+}
+
+// writeChanges writes only the cells which differ from the previous frame
+// the cursor is moved to each changed cell unless it directly follows the previously written cell,
+// and the graphic rendition is set explicitly if it differs from what the previously written cell left
+func (c *TermCanvas) writeChanges(b *bytes.Buffer, frame [][]renderedCell) {
+	var (
+		// each frame ends with the formatting cleared
+		state       sgrState
+		row, column = -1, -1
+		changed     bool
+	)
+
+	for i := range frame {
+		for j, cell := range frame[i] {
+			if cell.looksLike(c.prev[i][j]) {
 				continue
 			}
-			cellType, cellHash := cell.hash()
-			if cellString, ok := c.cellCaches[cellType][cellHash]; ok {
-				b.WriteString(cellString)
-				continue
+			if i != row || j != column {
+				b.Write(c.setCursor(i+1, j+1))
 			}
-			cellString := cell.String()
-			b.WriteString(cellString)
+			if cell.state != state {
+				b.WriteString(cell.state.String())
+			}
+			b.WriteString(cell.text)
 
-			c.cellCaches[cellType][cellHash] = cellString
+			state = cell.drawn
+			row, column = i, j+1
+			changed = true
 		}
-		b.WriteByte('\n')
-		b.Write(resetControl)
 	}
-	b.Write(resetControl)
 
-	// clear any potential formatting
-	_, err := c.dest.Write(b.Bytes())
-	return err
+	if changed {
+		// leave the cursor below the frame, as when writing the full frame
+		b.Write(c.resetFormatting())
+		b.Write(c.setCursor(len(frame)+1, 1))
+	}
+}
+
+// resetFormatting clears any formatting, unless the canvas doesn't use any
+func (c *TermCanvas) resetFormatting() []byte {
+	if c.profile == Monochrome {
+		return nil
+	}
+	return resetControl
+}
+
+func sameShape(prev, frame [][]renderedCell) bool {
+	if len(prev) != len(frame) {
+		return false
+	}
+	for i := range prev {
+		if len(prev[i]) != len(frame[i]) {
+			return false
+		}
+	}
+	return true
 }
 
 // UpdateCells updates the cells to be rendered
@@ -108,19 +263,18 @@ func (c *TermCanvas) UpdateCells(newCells [][]Cell) {
 	c.cells = newCells
 }
 
-func resetString() string {
-	switch runtime.GOOS {
-	case "linux":
-		return "\033c"
-	case "darwin":
-		return "\033[2J"
-	default:
-		return "\033[2J"
-	}
-}
+var (
+	// switch to the alternate screen then hide the cursor
+	enterAltScreen = []byte("\033[?1049h\033[?25l")
+	// show the cursor then return to the main screen
+	exitAltScreen = []byte("\033[?25h\033[?1049l")
+	// clear the screen without clearing the scrollback
+	clearScreen = []byte("\033[2J")
+)
 
 func (c *TermCanvas) clear() error {
-	_, err := c.dest.Write([]byte(resetString()))
+	c.prev = nil
+	_, err := c.dest.Write(clearScreen)
 	if err != nil {
 		return err
 	}
@@ -10,6 +10,7 @@ var renderTests = map[string]struct {
 	height           int
 	background       Color
 	debugMode        bool
+	options          []Option
 	cells            [][]Cell
 	expectedContents string
 }{
@@ -30,6 +31,46 @@ var renderTests = map[string]struct {
 		},
 		expectedContents: "\x1b[0;0H\u001b[47m\u001b[37m\u2588\u001b[47m\u001b[37m\u2588\n\u001b[0m\u001b[47m\u001b[37m\u2588\u001b[47m\u001b[37m\u2588\n\u001b[0m\u001b[0m",
 	},
+	"1x2 rgb cells, truecolor": {
+		width:      2,
+		height:     1,
+		background: Blue,
+		cells: [][]Cell{
+			{&BlockCell{Color: RGB(0, 240, 240)}, &TextCell{Text: "a", Color: RGB(240, 160, 0)}},
+		},
+		expectedContents: "\x1b[0;0H\u001b[48;2;0;240;240m\u001b[38;2;0;240;240m\u2588\u001b[38;2;240;160;0ma\n\u001b[0m\u001b[0m",
+	},
+	"1x2 rgb cells, 256 colors": {
+		width:      2,
+		height:     1,
+		background: Blue,
+		options:    []Option{WithColorProfile(ANSI256)},
+		cells: [][]Cell{
+			{&BlockCell{Color: RGB(0, 240, 240)}, &TextCell{Text: "a", Color: RGB(240, 160, 0)}},
+		},
+		expectedContents: "\x1b[0;0H\u001b[48;5;51m\u001b[38;5;51m\u2588\u001b[38;5;214ma\n\u001b[0m\u001b[0m",
+	},
+	"1x2 rgb cells, 16 colors": {
+		width:      2,
+		height:     1,
+		background: Blue,
+		options:    []Option{WithColorProfile(ANSI)},
+		cells: [][]Cell{
+			{&BlockCell{Color: RGB(0, 240, 240)}, &TextCell{Text: "a", Color: RGB(240, 160, 0)}},
+		},
+		expectedContents: "\x1b[0;0H\u001b[46m\u001b[36;1m\u2588\u001b[33ma\n\u001b[0m\u001b[0m",
+	},
+	"2x3 monochrome": {
+		width:      3,
+		height:     2,
+		background: Blue,
+		options:    []Option{WithColorProfile(Monochrome)},
+		cells: [][]Cell{
+			{&BlockCell{Color: Cyan, Label: "I"}, &BlockCell{Color: Cyan, Transparent: true, Label: "I"}, &TextCell{Text: "a", Color: Red}},
+			{&PipeCell{Type: BottomLeft, Color: Red}, &PipeCell{Type: HorizontalBar, Color: Red}},
+		},
+		expectedContents: "\x1b[0;0HI:a\n+-\n",
+	},
 }
 
 func TestRender(t *testing.T) {
@@ -39,6 +80,7 @@ func TestRender(t *testing.T) {
 		if test.debugMode {
 			opts = append(opts, WithDebugMode())
 		}
+		opts = append(opts, test.options...)
 		c := New(&b, opts...)
 
 		if len(test.cells) != 0 {
@@ -57,6 +99,185 @@ func TestRender(t *testing.T) {
 	}
 }
 
+var renderChangesTests = map[string]struct {
+	initialCells     [][]Cell
+	cells            [][]Cell
+	expectedContents string
+}{
+	"no changes": {
+		initialCells: [][]Cell{
+			{&BlockCell{Color: Red}, &BlockCell{Color: Red}},
+		},
+		cells: [][]Cell{
+			{&BlockCell{Color: Red}, &BlockCell{Color: Red}},
+		},
+		expectedContents: "",
+	},
+	"single cell changed": {
+		initialCells: [][]Cell{
+			{&BlockCell{Color: Red}, &BlockCell{Color: Red}},
+			{&BlockCell{Color: Red}, &BlockCell{Color: Red}},
+		},
+		cells: [][]Cell{
+			{&BlockCell{Color: Red}, &BlockCell{Color: Red}},
+			{&BlockCell{Color: Red}, &BlockCell{Color: Blue}},
+		},
+		expectedContents: "\x1b[2;2H\x1b[0;31;41m\x1b[44m\x1b[34m\u2588\x1b[0m\x1b[3;1H",
+	},
+	"adjacent cells changed": {
+		initialCells: [][]Cell{
+			{&BlockCell{Color: Red}, &BlockCell{Color: Red}, &BlockCell{Color: Red}},
+		},
+		cells: [][]Cell{
+			{&BlockCell{Color: Blue}, &BlockCell{Color: Blue}, &BlockCell{Color: Red}},
+		},
+		expectedContents: "\x1b[1;1H\x1b[44m\x1b[34m\u2588\x1b[44m\x1b[34m\u2588\x1b[0m\x1b[2;1H",
+	},
+	"unchanged cell after changed background": {
+		// the pipe only sets its foreground, so it must be rewritten when the background before it changes
+		initialCells: [][]Cell{
+			{&BlockCell{Color: Red}, &PipeCell{Type: VerticalBar, Color: White}},
+		},
+		cells: [][]Cell{
+			{&BlockCell{Color: Blue}, &PipeCell{Type: VerticalBar, Color: White}},
+		},
+		expectedContents: "\x1b[1;1H\x1b[44m\x1b[34m\u2588\x1b[37m\u2551\x1b[0m\x1b[2;1H",
+	},
+	"frame size changed": {
+		initialCells: [][]Cell{
+			{&BlockCell{Color: Red}},
+		},
+		cells: [][]Cell{
+			{&BlockCell{Color: Red}, &BlockCell{Color: Red}},
+		},
+		expectedContents: "\x1b[0;0H\x1b[41m\x1b[31m\u2588\x1b[41m\x1b[31m\u2588\n\x1b[0m\x1b[0m",
+	},
+}
+
+func TestRenderChanges(t *testing.T) {
+	for testName, test := range renderChangesTests {
+		var b bytes.Buffer
+		c := New(&b)
+
+		c.UpdateCells(test.initialCells)
+		if err := c.Render(); err != nil {
+			t.Fatalf("Unexpected error rendering initial cells for test case '%s': %s", testName, err)
+		}
+
+		b.Reset()
+		c.UpdateCells(test.cells)
+		if err := c.Render(); err != nil {
+			t.Fatalf("Unexpected error rendering cells for test case '%s': %s", testName, err)
+		}
+
+		contents := b.String()
+		if contents != test.expectedContents {
+			t.Errorf("Unexpected contents for test case '%s' [expected = %#v, actual = %#v]", testName, test.expectedContents, contents)
+		}
+	}
+}
+
+func TestRenderAfterInit(t *testing.T) {
+	var b bytes.Buffer
+	c := New(&b, WithWidth(1), WithHeight(1))
+
+	if err := c.Render(); err != nil {
+		t.Fatalf("Unexpected error rendering canvas: %s", err)
+	}
+	if err := c.Init(); err != nil {
+		t.Fatalf("Unexpected error initializing canvas: %s", err)
+	}
+
+	// the screen was cleared, so the full frame must be written again
+	b.Reset()
+	if err := c.Render(); err != nil {
+		t.Fatalf("Unexpected error rendering canvas: %s", err)
+	}
+	expected := "\x1b[0;0H\x1b[37m\u2588\n\x1b[0m\x1b[0m"
+	if b.String() != expected {
+		t.Errorf("Unexpected contents after init [expected = %#v, actual = %#v]", expected, b.String())
+	}
+}
+
+var initCloseTests = map[string]struct {
+	options       []Option
+	expectedInit  string
+	expectedClose string
+}{
+	"default": {
+		expectedInit:  "\x1b[?1049h\x1b[?25l\x1b[2J",
+		expectedClose: "\x1b[?25h\x1b[?1049l",
+	},
+	"without alt screen": {
+		options:      []Option{WithoutAltScreen()},
+		expectedInit: "\x1b[2J",
+	},
+	"debug mode": {
+		options:      []Option{WithDebugMode()},
+		expectedInit: "\x1b[2J",
+	},
+}
+
+func TestInitClose(t *testing.T) {
+	for testName, test := range initCloseTests {
+		var b bytes.Buffer
+		c := New(&b, test.options...)
+
+		if err := c.Init(); err != nil {
+			t.Fatalf("Unexpected error initializing canvas for test case '%s': %s", testName, err)
+		}
+		if b.String() != test.expectedInit {
+			t.Errorf("Unexpected contents after init for test case '%s' [expected = %#v, actual = %#v]", testName, test.expectedInit, b.String())
+		}
+
+		b.Reset()
+		if err := c.Close(); err != nil {
+			t.Fatalf("Unexpected error closing canvas for test case '%s': %s", testName, err)
+		}
+		if b.String() != test.expectedClose {
+			t.Errorf("Unexpected contents after close for test case '%s' [expected = %#v, actual = %#v]", testName, test.expectedClose, b.String())
+		}
+	}
+}
+
+func TestInitTwice(t *testing.T) {
+	var b bytes.Buffer
+	c := New(&b)
+
+	expected := []string{
+		"\x1b[?1049h\x1b[?25l\x1b[2J", // switches to the alternate screen
+		"\x1b[2J",                     // already on the alternate screen, so only clears it
+	}
+	for i := range expected {
+		b.Reset()
+		if err := c.Init(); err != nil {
+			t.Fatalf("Unexpected error initializing canvas: %s", err)
+		}
+		if b.String() != expected[i] {
+			t.Errorf("Unexpected contents after init %d [expected = %#v, actual = %#v]", i+1, expected[i], b.String())
+		}
+	}
+
+	for i, expected := range []string{"\x1b[?25h\x1b[?1049l", ""} {
+		b.Reset()
+		if err := c.Close(); err != nil {
+			t.Fatalf("Unexpected error closing canvas: %s", err)
+		}
+		if b.String() != expected {
+			t.Errorf("Unexpected contents after close %d [expected = %#v, actual = %#v]", i+1, expected, b.String())
+		}
+	}
+
+	// closed, so initializing again switches back to the alternate screen
+	b.Reset()
+	if err := c.Init(); err != nil {
+		t.Fatalf("Unexpected error initializing canvas: %s", err)
+	}
+	if b.String() != expected[0] {
+		t.Errorf("Unexpected contents after init once closed [expected = %#v, actual = %#v]", expected[0], b.String())
+	}
+}
+
 var renderBenchmarks = []struct {
 	name  string
 	size  int
@@ -145,6 +366,7 @@ func BenchmarkRender(b *testing.B) {
 		}
 
 		b.Run(name, func(b *testing.B) {
+			buf.Reset()
 			var err error
 			for n := 0; n < b.N; n++ {
 				err = c.Render()
@@ -153,10 +375,94 @@ func BenchmarkRender(b *testing.B) {
 				}
 			}
 			benchRes = err
+			b.Logf("%.0f B/frame", float64(buf.Len())/float64(b.N))
 		})
 	}
 }
 
+var renderMoveBenchmarks = []struct {
+	name   string
+	frames [][][]Cell
+}{
+	{
+		name:   "10x20 piece moves right",
+		frames: pieceFrames(10, 20, []int{3, 4}, []int{0, 0}),
+	},
+	{
+		name:   "10x20 piece falls",
+		frames: pieceFrames(10, 20, []int{3, 3}, []int{0, 1}),
+	},
+	{
+		name:   "10x20 piece hard drops",
+		frames: pieceFrames(10, 20, []int{3, 3}, []int{0, 18}),
+	},
+	{
+		name:   "boxed 10x20 piece moves right",
+		frames: boxFrames(pieceFrames(10, 20, []int{3, 4}, []int{0, 0})),
+	},
+}
+
+// BenchmarkRenderMoves compares writing every cell (as in debug mode) to only writing the changes for typical moves
+func BenchmarkRenderMoves(b *testing.B) {
+	for _, benchmark := range renderMoveBenchmarks {
+		for _, full := range []bool{true, false} {
+			var (
+				buf    bytes.Buffer
+				frames = benchmark.frames
+				name   = benchmark.name + ", changes only"
+				opts   = []Option{}
+			)
+			if full {
+				name = benchmark.name + ", full frame"
+				opts = append(opts, WithDebugMode())
+			}
+			c := New(&buf, opts...)
+			c.UpdateCells(frames[len(frames)-1])
+			if err := c.Render(); err != nil {
+				b.Fatalf("Error rendering canvas: %s", err)
+			}
+
+			b.Run(name, func(b *testing.B) {
+				buf.Reset()
+				var err error
+				for n := 0; n < b.N; n++ {
+					c.UpdateCells(frames[n%len(frames)])
+					err = c.Render()
+					if err != nil {
+						b.Errorf("Error rendering canvas: %s", err)
+					}
+				}
+				benchRes = err
+				b.Logf("%.0f B/frame", float64(buf.Len())/float64(b.N))
+			})
+		}
+	}
+}
+
+// pieceFrames generates a frame for each position of a T shaped piece on an otherwise empty board
+func pieceFrames(width, height int, xs, ys []int) [][][]Cell {
+	frames := [][][]Cell{}
+	for i := range xs {
+		cells := make([][]Cell, height)
+		for j := range cells {
+			cells[j] = make([]Cell, width)
+		}
+		for _, offset := range [][2]int{{0, 0}, {1, 0}, {2, 0}, {1, 1}} {
+			cells[ys[i]+offset[1]][xs[i]+offset[0]] = &BlockCell{Color: Magenta, Background: Black}
+		}
+		frames = append(frames, cells)
+	}
+	return frames
+}
+
+func boxFrames(frames [][][]Cell) [][][]Cell {
+	boxed := [][][]Cell{}
+	for _, frame := range frames {
+		boxed = append(boxed, Box(frame, "TEST"))
+	}
+	return boxed
+}
+
 // generates grid of cells for testing
 func populateCells(size int, transparent bool, box bool) [][]Cell {
 	colors := []Color{Black, Red, Green, Yellow, Blue, Magenta, Cyan, White}
@@ -1,23 +1,29 @@
 package canvas
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 const (
 	blockCellType = iota
 	pipeCellType
 	textCellType
+	halfBlockCellType
 )
 
 func cellTypes() []int {
-	return []int{blockCellType, pipeCellType, textCellType}
+	return []int{blockCellType, pipeCellType, textCellType, halfBlockCellType}
 }
 
 // Cell represents an item to be rendered on the canvas
 type Cell interface {
 	String() string
+	// render formats the cell using only the colors supported by the profile
+	render(p ColorProfile) string
 	hash() (int, string)
 }
 
@@ -27,6 +33,9 @@ const (
 	lightTransparentBlock  = "\u2591"
 	mediumTransparentBlock = "\u2592"
 	darkTransparentBlock   = "\u2593"
+	outlineBlock           = "\u25A1"
+	upperHalfBlock         = "\u2580"
+	lowerHalfBlock         = "\u2584"
 
 	// pipe elements
 	horizontalBarPipe = "\u2550"
@@ -35,27 +44,206 @@ const (
 	topRightPipe      = "\u2557"
 	bottomLeftPipe    = "\u255A"
 	bottomRightPipe   = "\u255D"
+
+	// plain elements, used when colors aren't supported
+	plainBlock            = "#"
+	plainTransparentBlock = ":"
+	plainEmptyBlock       = " "
+	plainHorizontalBar    = "-"
+	plainVerticalBar      = "|"
+	plainCorner           = "+"
+)
+
+// GhostStyle is how transparent blocks (e.g. the ghost piece) are drawn
+// along with the named styles, any single character can be used as a custom glyph
+type GhostStyle string
+
+// the available ghost styles
+const (
+	MediumGhost  GhostStyle = "medium"
+	LightGhost   GhostStyle = "light"
+	DarkGhost    GhostStyle = "dark"
+	OutlineGhost GhostStyle = "outline" // an outlined square
+	DimGhost     GhostStyle = "dim"     // a solid block drawn with a dimmed color
 )
 
+// GhostStyles returns every named ghost style
+func GhostStyles() []GhostStyle {
+	return []GhostStyle{MediumGhost, LightGhost, DarkGhost, OutlineGhost, DimGhost}
+}
+
+func (g GhostStyle) String() string {
+	if g == "" {
+		return string(MediumGhost)
+	}
+	return string(g)
+}
+
+// GhostStyleFromName returns the ghost style with the specified name, or a custom style if the name is a single character
+// custom glyphs must take up a single column, since every cell is drawn in one column
+func GhostStyleFromName(name string) (GhostStyle, error) {
+	for _, g := range GhostStyles() {
+		if g.String() == strings.ToLower(name) {
+			return g, nil
+		}
+	}
+	r, size := utf8.DecodeRuneInString(name)
+	if size != len(name) || r == utf8.RuneError || !unicode.IsPrint(r) || unicode.IsSpace(r) {
+		return "", fmt.Errorf("unrecognized ghost style: '%s'", name)
+	}
+	if !singleColumn(r) {
+		return "", fmt.Errorf("ghost style '%s' isn't a single column wide", name)
+	}
+	return GhostStyle(name), nil
+}
+
+// singleColumn reports whether a printable rune is displayed in a single column of the terminal
+// combining marks take up no columns, while wide characters (e.g. CJK and emoji) take up two
+func singleColumn(r rune) bool {
+	return !unicode.In(r, unicode.Mn, unicode.Me, wideRunes)
+}
+
+// wideRunes are the ranges of characters which most terminals display using two columns
+// based on the wide and fullwidth characters of Unicode's East Asian Width property, which includes emoji
+var wideRunes = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x115f, Stride: 1}, // hangul jamo
+		{Lo: 0x231a, Hi: 0x231b, Stride: 1},
+		{Lo: 0x2329, Hi: 0x232a, Stride: 1},
+		{Lo: 0x23e9, Hi: 0x23ec, Stride: 1},
+		{Lo: 0x23f0, Hi: 0x23f3, Stride: 3},
+		{Lo: 0x25fd, Hi: 0x25fe, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2615, Stride: 1},
+		{Lo: 0x2648, Hi: 0x2653, Stride: 1},
+		{Lo: 0x267f, Hi: 0x2693, Stride: 20},
+		{Lo: 0x26a1, Hi: 0x26a1, Stride: 1},
+		{Lo: 0x26aa, Hi: 0x26ab, Stride: 1},
+		{Lo: 0x26bd, Hi: 0x26be, Stride: 1},
+		{Lo: 0x26c4, Hi: 0x26c5, Stride: 1},
+		{Lo: 0x26ce, Hi: 0x26d4, Stride: 6},
+		{Lo: 0x26ea, Hi: 0x26ea, Stride: 1},
+		{Lo: 0x26f2, Hi: 0x26f3, Stride: 1},
+		{Lo: 0x26f5, Hi: 0x26fa, Stride: 5},
+		{Lo: 0x26fd, Hi: 0x2705, Stride: 8},
+		{Lo: 0x270a, Hi: 0x270b, Stride: 1},
+		{Lo: 0x2728, Hi: 0x274c, Stride: 36},
+		{Lo: 0x274e, Hi: 0x274e, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27b0, Hi: 0x27bf, Stride: 15},
+		{Lo: 0x2b1b, Hi: 0x2b1c, Stride: 1},
+		{Lo: 0x2b50, Hi: 0x2b55, Stride: 5},
+		{Lo: 0x2e80, Hi: 0x303e, Stride: 1}, // cjk radicals, symbols and punctuation
+		{Lo: 0x3041, Hi: 0x33ff, Stride: 1}, // kana, bopomofo and cjk compatibility
+		{Lo: 0x3400, Hi: 0x4dbf, Stride: 1}, // cjk unified ideographs extension a
+		{Lo: 0x4e00, Hi: 0xa4cf, Stride: 1}, // cjk unified ideographs and yi
+		{Lo: 0xa960, Hi: 0xa97f, Stride: 1},
+		{Lo: 0xac00, Hi: 0xd7a3, Stride: 1}, // hangul syllables
+		{Lo: 0xf900, Hi: 0xfaff, Stride: 1}, // cjk compatibility ideographs
+		{Lo: 0xfe10, Hi: 0xfe19, Stride: 1},
+		{Lo: 0xfe30, Hi: 0xfe6f, Stride: 1},
+		{Lo: 0xff00, Hi: 0xff60, Stride: 1}, // fullwidth forms
+		{Lo: 0xffe0, Hi: 0xffe6, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x16fe0, Hi: 0x18cff, Stride: 1}, // tangut and khitan
+		{Lo: 0x1b000, Hi: 0x1b2ff, Stride: 1}, // kana supplement and nushu
+		{Lo: 0x1f004, Hi: 0x1f0cf, Stride: 203},
+		{Lo: 0x1f18e, Hi: 0x1f18e, Stride: 1},
+		{Lo: 0x1f191, Hi: 0x1f19a, Stride: 1},
+		{Lo: 0x1f200, Hi: 0x1f2ff, Stride: 1}, // enclosed ideographic supplement
+		{Lo: 0x1f300, Hi: 0x1f64f, Stride: 1}, // emoji
+		{Lo: 0x1f680, Hi: 0x1f6ff, Stride: 1},
+		{Lo: 0x1f7e0, Hi: 0x1f7eb, Stride: 1},
+		{Lo: 0x1f900, Hi: 0x1f9ff, Stride: 1},
+		{Lo: 0x1fa70, Hi: 0x1faff, Stride: 1},
+		{Lo: 0x20000, Hi: 0x3fffd, Stride: 1}, // cjk unified ideographs extensions
+	},
+}
+
+// draw formats a transparent block of the specified color
+func (g GhostStyle) draw(color Color) string {
+	switch g {
+	case "", MediumGhost:
+		return color.decorate(mediumTransparentBlock)
+	case LightGhost:
+		return color.decorate(lightTransparentBlock)
+	case DarkGhost:
+		return color.decorate(darkTransparentBlock)
+	case OutlineGhost:
+		return color.decorate(outlineBlock)
+	case DimGhost:
+		return faintControl + color.decorate(block) + normalIntensityControl
+	default:
+		return color.decorate(string(g))
+	}
+}
+
+// plain represents a transparent block without any colors
+// custom glyphs are kept, since they don't rely on color
+func (g GhostStyle) plain() string {
+	if g.custom() {
+		return string(g)
+	}
+	return plainTransparentBlock
+}
+
+func (g GhostStyle) custom() bool {
+	if g == "" {
+		return false
+	}
+	for _, named := range GhostStyles() {
+		if g == named {
+			return false
+		}
+	}
+	return true
+}
+
 // BlockCell represents a single cell on the canvas
 type BlockCell struct {
 	Color       Color
 	Background  Color
 	Transparent bool
+	Ghost       GhostStyle // only used if transparent
+	Label       string     // drawn instead of the block if colors aren't supported
 }
 
 func (c *BlockCell) String() string {
+	return c.render(TrueColor)
+}
+
+func (c *BlockCell) render(p ColorProfile) string {
+	if p == Monochrome {
+		return c.plain()
+	}
+	color := c.Color.downgrade(p)
 	if c.Transparent {
-		return c.Background.background().decorate(
-			// TODO: allow for level of transparency to be modifiable
-			c.Color.decorate(mediumTransparentBlock),
+		return c.Background.downgrade(p).background().decorate(
+			c.Ghost.draw(color),
 		)
 	}
-	return c.Color.background().decorate(
-		c.Color.decorate(block),
+	return color.background().decorate(
+		color.decorate(block),
 	)
 }
 
+// plain represents the block without any colors
+// a block which matches its background is empty, since it would be indistinguishable from the background anyway
+func (c *BlockCell) plain() string {
+	switch {
+	case c.Transparent:
+		return c.Ghost.plain()
+	case c.Label != "":
+		return c.Label
+	case c.Color == c.Background:
+		return plainEmptyBlock
+	default:
+		return plainBlock
+	}
+}
+
 func (c *BlockCell) hash() (int, string) {
 	var b strings.Builder
 	col := strconv.Itoa(int(c.Color))
@@ -68,13 +256,98 @@ func (c *BlockCell) hash() (int, string) {
 
 	if c.Transparent {
 		b.WriteString("1")
+		b.WriteString(string(c.Ghost))
 	} else {
 		b.WriteString("0")
 	}
+	b.WriteString("_")
+
+	b.WriteString(c.Label)
 
 	return blockCellType, b.String()
 }
 
+// HalfBlockCell packs two vertically adjacent blocks into a single cell, so blocks are square without doubling their width
+// a transparent half (e.g. the ghost piece) is drawn with a dimmed color, since only solid halves can be drawn
+type HalfBlockCell struct {
+	Upper *BlockCell
+	Lower *BlockCell
+}
+
+func (h *HalfBlockCell) String() string {
+	return h.render(TrueColor)
+}
+
+func (h *HalfBlockCell) render(p ColorProfile) string {
+	if p == Monochrome {
+		if upper := h.Upper.plain(); upper != plainEmptyBlock {
+			return upper
+		}
+		return h.Lower.plain()
+	}
+
+	var (
+		upper = h.Upper.Color.downgrade(p)
+		lower = h.Lower.Color.downgrade(p)
+	)
+	switch {
+	case h.Upper.Transparent && h.Lower.Transparent:
+		return h.Upper.Background.downgrade(p).background().decorate(faintControl + upper.decorate(block) + normalIntensityControl)
+	case h.Upper.Transparent:
+		return lower.background().decorate(faintControl + upper.decorate(upperHalfBlock) + normalIntensityControl)
+	case h.Lower.Transparent:
+		return upper.background().decorate(faintControl + lower.decorate(lowerHalfBlock) + normalIntensityControl)
+	default:
+		return lower.background().decorate(upper.decorate(upperHalfBlock))
+	}
+}
+
+func (h *HalfBlockCell) hash() (int, string) {
+	var b strings.Builder
+	_, upper := h.Upper.hash()
+	b.WriteString(upper)
+	b.WriteString("|")
+
+	_, lower := h.Lower.hash()
+	b.WriteString(lower)
+
+	return halfBlockCellType, b.String()
+}
+
+// HalfBlocks packs each pair of rows of block cells into a single row of half block cells
+// if there are an odd number of rows the first row is paired with an empty row above it, so the bottom row is never split
+// any cells which aren't block cells are kept as they are
+func HalfBlocks(cells [][]Cell) [][]Cell {
+	if len(cells)%2 != 0 {
+		cells = append([][]Cell{nil}, cells...)
+	}
+
+	packed := make([][]Cell, 0, len(cells)/2)
+	for i := 0; i < len(cells); i += 2 {
+		var (
+			upperRow = cells[i]
+			lowerRow = cells[i+1]
+			row      = make([]Cell, len(lowerRow))
+		)
+		for j := range lowerRow {
+			lower, ok := lowerRow[j].(*BlockCell)
+			if !ok {
+				row[j] = lowerRow[j]
+				continue
+			}
+			upper := &BlockCell{Color: lower.Background, Background: lower.Background}
+			if j < len(upperRow) {
+				if cell, ok := upperRow[j].(*BlockCell); ok {
+					upper = cell
+				}
+			}
+			row[j] = &HalfBlockCell{Upper: upper, Lower: lower}
+		}
+		packed = append(packed, row)
+	}
+	return packed
+}
+
 // PipeType represents a type of pipe cell
 type PipeType int
 
@@ -95,19 +368,41 @@ type PipeCell struct {
 }
 
 func (p *PipeCell) String() string {
+	return p.render(TrueColor)
+}
+
+func (p *PipeCell) render(profile ColorProfile) string {
+	if profile == Monochrome {
+		return p.plain()
+	}
+	color := p.Color.downgrade(profile)
 	switch p.Type {
 	case HorizontalBar:
-		return p.Color.decorate(horizontalBarPipe)
+		return color.decorate(horizontalBarPipe)
 	case VerticalBar:
-		return p.Color.decorate(verticalBarPipe)
+		return color.decorate(verticalBarPipe)
 	case TopLeft:
-		return p.Color.decorate(topLeftPipe)
+		return color.decorate(topLeftPipe)
 	case TopRight:
-		return p.Color.decorate(topRightPipe)
+		return color.decorate(topRightPipe)
 	case BottomLeft:
-		return p.Color.decorate(bottomLeftPipe)
+		return color.decorate(bottomLeftPipe)
 	case BottomRight:
-		return p.Color.decorate(bottomRightPipe)
+		return color.decorate(bottomRightPipe)
+	default:
+		return ""
+	}
+}
+
+// plain represents the pipe using only ASCII characters
+func (p *PipeCell) plain() string {
+	switch p.Type {
+	case HorizontalBar:
+		return plainHorizontalBar
+	case VerticalBar:
+		return plainVerticalBar
+	case TopLeft, TopRight, BottomLeft, BottomRight:
+		return plainCorner
 	default:
 		return ""
 	}
@@ -125,12 +420,23 @@ func (p *PipeCell) hash() (int, string) {
 	return pipeCellType, b.String()
 }
 
+// BoxStyle specifies the colors used to draw a box
+type BoxStyle struct {
+	Border  Color
+	Caption Color
+}
+
 // Box wraps a block of cells in a piped box
 func Box(inner [][]Cell, caption string) [][]Cell {
+	return StyledBox(inner, caption, BoxStyle{})
+}
+
+// StyledBox wraps a block of cells in a piped box, drawn using the specified style
+func StyledBox(inner [][]Cell, caption string, style BoxStyle) [][]Cell {
 	boxedCells := [][]Cell{}
 
-	topRow := []Cell{&PipeCell{Type: TopLeft}}
-	bottomRow := []Cell{&PipeCell{Type: BottomLeft}}
+	topRow := []Cell{&PipeCell{Type: TopLeft, Color: style.Border}}
+	bottomRow := []Cell{&PipeCell{Type: BottomLeft, Color: style.Border}}
 
 	var bar []Cell
 	if len(caption) > len(inner[0]) {
@@ -139,11 +445,11 @@ func Box(inner [][]Cell, caption string) [][]Cell {
 		bar = make([]Cell, len(inner[0]))
 	}
 	for i := range bar {
-		bar[i] = &PipeCell{Type: HorizontalBar}
+		bar[i] = &PipeCell{Type: HorizontalBar, Color: style.Border}
 	}
 
 	bottomRow = append(bottomRow, bar...)
-	bottomRow = append(bottomRow, &PipeCell{Type: BottomRight})
+	bottomRow = append(bottomRow, &PipeCell{Type: BottomRight, Color: style.Border})
 
 	// center the text on the top bar
 	if caption != "" {
@@ -152,13 +458,14 @@ func Box(inner [][]Cell, caption string) [][]Cell {
 		)
 		for i := 0; i < len(caption); i++ {
 			bar[i+textStart] = &TextCell{
-				Text: string(caption[i]),
+				Text:  string(caption[i]),
+				Color: style.Caption,
 			}
 		}
 	}
 
 	topRow = append(topRow, bar...)
-	topRow = append(topRow, &PipeCell{Type: TopRight})
+	topRow = append(topRow, &PipeCell{Type: TopRight, Color: style.Border})
 
 	boxedCells = append(boxedCells, topRow)
 
@@ -169,8 +476,8 @@ func Box(inner [][]Cell, caption string) [][]Cell {
 			innerStart = (len(bar) + 2 - len(innerRow)) / 2
 			row        = make([]Cell, len(bar)+2)
 		)
-		row[0] = &PipeCell{Type: VerticalBar}
-		row[len(row)-1] = &PipeCell{Type: VerticalBar}
+		row[0] = &PipeCell{Type: VerticalBar, Color: style.Border}
+		row[len(row)-1] = &PipeCell{Type: VerticalBar, Color: style.Border}
 		for j := 1; j < len(row)-1; j++ {
 			if j < innerStart || j > len(innerRow)+innerStart-1 {
 				row[j] = &TextCell{
@@ -194,7 +501,14 @@ type TextCell struct {
 }
 
 func (t *TextCell) String() string {
-	return t.Color.decorate(t.Text)
+	return t.render(TrueColor)
+}
+
+func (t *TextCell) render(p ColorProfile) string {
+	if p == Monochrome {
+		return t.Text
+	}
+	return t.Color.downgrade(p).decorate(t.Text)
 }
 
 func (t *TextCell) hash() (int, string) {
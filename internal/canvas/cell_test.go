@@ -1,11 +1,15 @@
 package canvas
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 var cellColorTests = map[string]struct {
 	Color          Color
 	Background     Color
 	Transparent    bool
+	Ghost          GhostStyle
 	ExpectedString string
 }{
 	"Black solid": {
@@ -82,6 +86,46 @@ var cellColorTests = map[string]struct {
 		Background:     White,
 		ExpectedString: "\u001b[47m\u001b[36m\u2592",
 	},
+	"Cyan transparent light ghost": {
+		Color:          Cyan,
+		Transparent:    true,
+		Background:     White,
+		Ghost:          LightGhost,
+		ExpectedString: "\u001b[47m\u001b[36m\u2591",
+	},
+	"Cyan transparent dark ghost": {
+		Color:          Cyan,
+		Transparent:    true,
+		Background:     White,
+		Ghost:          DarkGhost,
+		ExpectedString: "\u001b[47m\u001b[36m\u2593",
+	},
+	"Cyan transparent outline ghost": {
+		Color:          Cyan,
+		Transparent:    true,
+		Background:     White,
+		Ghost:          OutlineGhost,
+		ExpectedString: "\u001b[47m\u001b[36m\u25A1",
+	},
+	"Cyan transparent dim ghost": {
+		Color:          Cyan,
+		Transparent:    true,
+		Background:     White,
+		Ghost:          DimGhost,
+		ExpectedString: "\u001b[47m\u001b[2m\u001b[36m\u2588\u001b[22m",
+	},
+	"Cyan transparent custom ghost": {
+		Color:          Cyan,
+		Transparent:    true,
+		Background:     White,
+		Ghost:          "*",
+		ExpectedString: "\u001b[47m\u001b[36m*",
+	},
+	"Cyan solid dark ghost": {
+		Color:          Cyan,
+		Ghost:          DarkGhost,
+		ExpectedString: "\u001b[46m\u001b[36m\u2588",
+	},
 }
 
 func TestCellColors(t *testing.T) {
@@ -90,6 +134,7 @@ func TestCellColors(t *testing.T) {
 			Color:       test.Color,
 			Transparent: test.Transparent,
 			Background:  test.Background,
+			Ghost:       test.Ghost,
 		}
 
 		cellString := cell.String()
@@ -313,3 +358,184 @@ func TestCellFromString(t *testing.T) {
 		}
 	}
 }
+
+func TestStyledBox(t *testing.T) {
+	var (
+		inner = [][]Cell{{&TextCell{Text: "a", Color: White}}}
+		style = BoxStyle{Border: Blue, Caption: Red}
+		boxed = StyledBox(inner, "B", style)
+	)
+
+	for i, row := range boxed {
+		for j, cell := range row {
+			switch cell := cell.(type) {
+			case *PipeCell:
+				if cell.Color != style.Border {
+					t.Errorf("Unexpected border color at (%d, %d) [expected = %s, actual = %s]", i, j, style.Border.description(), cell.Color.description())
+				}
+			case *TextCell:
+				if i == 0 && cell.Color != style.Caption {
+					t.Errorf("Unexpected caption color at (%d, %d) [expected = %s, actual = %s]", i, j, style.Caption.description(), cell.Color.description())
+				}
+			}
+		}
+	}
+	if boxed[1][1] != inner[0][0] {
+		t.Errorf("Unexpected inner cell [expected = %v, actual = %v]", inner[0][0], boxed[1][1])
+	}
+}
+
+var plainCellTests = map[string]struct {
+	cell     Cell
+	expected string
+}{
+	"labeled block": {
+		cell:     &BlockCell{Color: Red, Background: White, Label: "Z"},
+		expected: "Z",
+	},
+	"unlabeled block": {
+		cell:     &BlockCell{Color: Red, Background: White},
+		expected: "#",
+	},
+	"empty block": {
+		cell:     &BlockCell{Color: White, Background: White},
+		expected: " ",
+	},
+	"transparent block": {
+		cell:     &BlockCell{Color: Red, Background: White, Transparent: true, Ghost: DarkGhost, Label: "Z"},
+		expected: ":",
+	},
+	"custom transparent block": {
+		cell:     &BlockCell{Color: Red, Background: White, Transparent: true, Ghost: "*", Label: "Z"},
+		expected: "*",
+	},
+	"horizontal bar": {
+		cell:     &PipeCell{Type: HorizontalBar, Color: Blue},
+		expected: "-",
+	},
+	"vertical bar": {
+		cell:     &PipeCell{Type: VerticalBar, Color: Blue},
+		expected: "|",
+	},
+	"corner": {
+		cell:     &PipeCell{Type: TopRight, Color: Blue},
+		expected: "+",
+	},
+	"text": {
+		cell:     &TextCell{Text: "a", Color: Blue},
+		expected: "a",
+	},
+}
+
+func TestPlainCells(t *testing.T) {
+	for testName, test := range plainCellTests {
+		if actual := test.cell.render(Monochrome); actual != test.expected {
+			t.Errorf("Unexpected string for test case '%s' [expected = %q, actual = %q]", testName, test.expected, actual)
+		}
+	}
+}
+
+func TestGhostStyleFromName(t *testing.T) {
+	for _, expected := range GhostStyles() {
+		style, err := GhostStyleFromName(strings.ToUpper(expected.String()))
+		if err != nil {
+			t.Errorf("Unexpected error finding ghost style '%s': %s", expected, err)
+		} else if style != expected {
+			t.Errorf("Unexpected ghost style [expected = %s, actual = %s]", expected, style)
+		}
+	}
+
+	if style, err := GhostStyleFromName("\u25CB"); err != nil || style != "\u25CB" {
+		t.Errorf("Unexpected custom ghost style [style = %s, err = %v]", style, err)
+	}
+
+	// combining marks, CJK and emoji don't take up a single column
+	for _, name := range []string{"transparent", "", " ", "\t", "ab", "\u0301", "\u25A1\u0301", "\u6F22", "\uFF21", "\U0001F47B", "\u2B50"} {
+		if _, err := GhostStyleFromName(name); err == nil {
+			t.Errorf("Unexpectedly no error finding ghost style '%s'", name)
+		}
+	}
+}
+
+var halfBlockCellTests = map[string]struct {
+	cell     *HalfBlockCell
+	profile  ColorProfile
+	expected string
+}{
+	"solid halves": {
+		cell:     &HalfBlockCell{Upper: &BlockCell{Color: Red, Background: White}, Lower: &BlockCell{Color: Blue, Background: White}},
+		profile:  TrueColor,
+		expected: "\u001b[44m\u001b[31m\u2580",
+	},
+	"transparent upper half": {
+		cell:     &HalfBlockCell{Upper: &BlockCell{Color: Red, Background: White, Transparent: true}, Lower: &BlockCell{Color: White, Background: White}},
+		profile:  TrueColor,
+		expected: "\u001b[47m\u001b[2m\u001b[31m\u2580\u001b[22m",
+	},
+	"transparent lower half": {
+		cell:     &HalfBlockCell{Upper: &BlockCell{Color: Blue, Background: White}, Lower: &BlockCell{Color: Red, Background: White, Transparent: true}},
+		profile:  TrueColor,
+		expected: "\u001b[44m\u001b[2m\u001b[31m\u2584\u001b[22m",
+	},
+	"transparent halves": {
+		cell:     &HalfBlockCell{Upper: &BlockCell{Color: Red, Background: White, Transparent: true}, Lower: &BlockCell{Color: Red, Background: White, Transparent: true}},
+		profile:  TrueColor,
+		expected: "\u001b[47m\u001b[2m\u001b[31m\u2588\u001b[22m",
+	},
+	"downgraded colors": {
+		cell:     &HalfBlockCell{Upper: &BlockCell{Color: RGB(240, 0, 0), Background: White}, Lower: &BlockCell{Color: RGB(0, 0, 240), Background: White}},
+		profile:  ANSI,
+		expected: "\u001b[44m\u001b[31;1m\u2580",
+	},
+	"monochrome empty upper half": {
+		cell:     &HalfBlockCell{Upper: &BlockCell{Color: White, Background: White}, Lower: &BlockCell{Color: Red, Background: White, Label: "Z"}},
+		profile:  Monochrome,
+		expected: "Z",
+	},
+	"monochrome upper half": {
+		cell:     &HalfBlockCell{Upper: &BlockCell{Color: Blue, Background: White, Label: "J"}, Lower: &BlockCell{Color: Red, Background: White, Label: "Z"}},
+		profile:  Monochrome,
+		expected: "J",
+	},
+}
+
+func TestHalfBlockCell(t *testing.T) {
+	for testName, test := range halfBlockCellTests {
+		if actual := test.cell.render(test.profile); actual != test.expected {
+			t.Errorf("Unexpected string for test case '%s' [expected = %q, actual = %q]", testName, test.expected, actual)
+		}
+	}
+}
+
+func TestHalfBlocks(t *testing.T) {
+	var (
+		top    = &BlockCell{Color: Red, Background: White}
+		middle = &BlockCell{Color: Blue, Background: White}
+		bottom = &BlockCell{Color: White, Background: White}
+		text   = &TextCell{Text: "a", Color: Reset}
+		cells  = [][]Cell{{top, text}, {middle, text}, {bottom, text}}
+		packed = HalfBlocks(cells)
+	)
+
+	if len(packed) != 2 {
+		t.Fatalf("Unexpected number of rows [expected = 2, actual = %d]", len(packed))
+	}
+
+	expected := []*HalfBlockCell{
+		{Upper: &BlockCell{Color: White, Background: White}, Lower: top},
+		{Upper: middle, Lower: bottom},
+	}
+	for i, row := range packed {
+		cell, ok := row[0].(*HalfBlockCell)
+		if !ok {
+			t.Errorf("Unexpected cell at (%d, 0) [expected = half block, actual = %T]", i, row[0])
+			continue
+		}
+		if *cell.Upper != *expected[i].Upper || cell.Lower != expected[i].Lower {
+			t.Errorf("Unexpected cell at (%d, 0) [expected = %+v/%+v, actual = %+v/%+v]", i, *expected[i].Upper, *expected[i].Lower, *cell.Upper, *cell.Lower)
+		}
+		if row[1] != text {
+			t.Errorf("Unexpected cell at (%d, 1) [expected = %v, actual = %v]", i, text, row[1])
+		}
+	}
+}
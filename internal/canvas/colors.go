@@ -1,6 +1,7 @@
 package canvas
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 )
@@ -42,32 +43,78 @@ const (
 	BackgroundWhite
 
 	// other colors
-	Orange           Color = 208
-	BackgroundOrange Color = -208
+	Orange           Color = paletteFlag | 208
+	BackgroundOrange Color = backgroundFlag | Orange
 )
 
+// flags used to encode colors beyond the basic SGR codes
+// palette colors store the index (0-255) in the lowest byte, RGB colors store 0xRRGGBB in the lowest 3 bytes
+const (
+	paletteFlag    Color = 1 << 24
+	rgbFlag        Color = 1 << 25
+	backgroundFlag Color = 1 << 26 // only used with palette and RGB colors
+
+	colorValueMask Color = 1<<24 - 1
+)
+
+// Palette returns the color with the specified index in the 256 color palette
+func Palette(index uint8) Color {
+	return paletteFlag | Color(index)
+}
+
+// RGB returns a 24-bit (truecolor) color
+func RGB(r, g, b uint8) Color {
+	return rgbFlag | Color(r)<<16 | Color(g)<<8 | Color(b)
+}
+
+func (c Color) isPalette() bool    { return c&paletteFlag != 0 }
+func (c Color) isRGB() bool        { return c&rgbFlag != 0 }
+func (c Color) isBackground() bool { return c&backgroundFlag != 0 }
+
+// rgb returns the components of an RGB color
+func (c Color) rgb() (r, g, b uint8) {
+	return uint8(c >> 16), uint8(c >> 8), uint8(c)
+}
+
 var resetControl = []byte{'\u001b', '[', '0', 'm'}
 
+// faintControl dims the following text until normalIntensityControl (which also clears bold)
+const (
+	faintControl           = "\u001b[2m"
+	normalIntensityControl = "\u001b[22m"
+)
+
 func (c Color) String() string {
 	var b strings.Builder
 	b.Grow(5)
 	b.WriteString("\u001b[")
-	switch c {
-	case BrightBlack, BrightRed, BrightGreen, BrightYellow, BrightBlue, BrightMagenta, BrightCyan, BrightWhite:
+	switch {
+	case c.isPalette() || c.isRGB():
+		if c.isBackground() {
+			b.WriteString("48;")
+		} else {
+			b.WriteString("38;")
+		}
+		if c.isPalette() {
+			b.WriteString("5;")
+			b.WriteString(strconv.Itoa(int(c & colorValueMask)))
+		} else {
+			r, g, bl := c.rgb()
+			b.WriteString("2;")
+			b.WriteString(strconv.Itoa(int(r)))
+			b.WriteString(";")
+			b.WriteString(strconv.Itoa(int(g)))
+			b.WriteString(";")
+			b.WriteString(strconv.Itoa(int(bl)))
+		}
+		b.WriteString("m")
+	case c >= BrightBlack && c <= BrightWhite:
 		b.WriteString(strconv.Itoa(int(c) - 8))
 		b.WriteString(";1m")
-	case BackgroundBlack, BackgroundRed, BackgroundGreen, BackgroundYellow, BackgroundBlue, BackgroundMagenta, BackgroundCyan, BackgroundWhite:
+	case c >= BackgroundBlack && c <= BackgroundWhite:
 		b.WriteString(strconv.Itoa(int(c) - 6))
 		b.WriteString("m")
-	case Reset, Black, Red, Green, Yellow, Blue, Magenta, Cyan, White:
-		b.WriteString(strconv.Itoa(int(c)))
-		b.WriteString("m")
-	case BackgroundOrange:
-		b.WriteString("48;5;")
-		b.WriteString(strconv.Itoa(-1 * int(c)))
-		b.WriteString("m")
 	default:
-		b.WriteString("38;5;")
 		b.WriteString(strconv.Itoa(int(c)))
 		b.WriteString("m")
 	}
@@ -101,10 +148,27 @@ func (c Color) description() string {
 		BackgroundCyan:    "background cyan",
 		BackgroundWhite:   "background white",
 		Orange:            "orange",
+		BackgroundOrange:  "background orange",
 		Reset:             "reset",
 	}
 
-	return colorDescriptions[c]
+	if description, ok := colorDescriptions[c]; ok {
+		return description
+	}
+
+	var description string
+	switch {
+	case c.isPalette():
+		description = fmt.Sprintf("palette %d", c&colorValueMask)
+	case c.isRGB():
+		description = fmt.Sprintf("#%06x", int(c&colorValueMask))
+	default:
+		return ""
+	}
+	if c.isBackground() {
+		return "background " + description
+	}
+	return description
 }
 
 // decorate formats the provided input so it can be printed in color
@@ -134,9 +198,39 @@ func (c Color) background() Color {
 		return BackgroundCyan
 	case White, BrightWhite:
 		return BackgroundWhite
-	case Orange:
-		return BackgroundOrange
 	default:
+		if c.isPalette() || c.isRGB() {
+			return c | backgroundFlag
+		}
 		return c
 	}
 }
+
+// ParseColor parses a color from either its name (e.g. "bright red"), a hex RGB value (e.g. "#00f0f0"),
+// or an index in the 256 color palette (e.g. "208")
+func ParseColor(s string) (Color, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	name = strings.NewReplacer("-", " ", "_", " ").Replace(name)
+
+	switch {
+	case name == "reset" || name == "default":
+		return Reset, nil
+	case strings.HasPrefix(name, "#") && len(name) == 7:
+		rgb, err := strconv.ParseUint(name[1:], 16, 32)
+		if err != nil {
+			return 0, fmt.Errorf("unrecognized color: '%s'", s)
+		}
+		return RGB(uint8(rgb>>16), uint8(rgb>>8), uint8(rgb)), nil
+	}
+
+	if index, err := strconv.ParseUint(name, 10, 8); err == nil {
+		return Palette(uint8(index)), nil
+	}
+
+	for _, c := range []Color{Black, Red, Green, Yellow, Blue, Magenta, Cyan, White, BrightBlack, BrightRed, BrightGreen, BrightYellow, BrightBlue, BrightMagenta, BrightCyan, BrightWhite, Orange} {
+		if c.description() == name {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("unrecognized color: '%s'", s)
+}
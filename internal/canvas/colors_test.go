@@ -152,6 +152,36 @@ var colorTests = map[Color]struct {
 		ExpectedDecoratedInput: "\u001b[47minput",
 		ExpectedBackground:     BackgroundWhite,
 	},
+	Orange: {
+		ExpectedDescription:    "orange",
+		ExpectedString:         "\u001b[38;5;208m",
+		ExpectedDecoratedInput: "\u001b[38;5;208minput",
+		ExpectedBackground:     BackgroundOrange,
+	},
+	BackgroundOrange: {
+		ExpectedDescription:    "background orange",
+		ExpectedString:         "\u001b[48;5;208m",
+		ExpectedDecoratedInput: "\u001b[48;5;208minput",
+		ExpectedBackground:     BackgroundOrange,
+	},
+	Palette(93): {
+		ExpectedDescription:    "palette 93",
+		ExpectedString:         "\u001b[38;5;93m",
+		ExpectedDecoratedInput: "\u001b[38;5;93minput",
+		ExpectedBackground:     Palette(93) | backgroundFlag,
+	},
+	RGB(0, 240, 240): {
+		ExpectedDescription:    "#00f0f0",
+		ExpectedString:         "\u001b[38;2;0;240;240m",
+		ExpectedDecoratedInput: "\u001b[38;2;0;240;240minput",
+		ExpectedBackground:     RGB(0, 240, 240) | backgroundFlag,
+	},
+	RGB(0, 240, 240) | backgroundFlag: {
+		ExpectedDescription:    "background #00f0f0",
+		ExpectedString:         "\u001b[48;2;0;240;240m",
+		ExpectedDecoratedInput: "\u001b[48;2;0;240;240minput",
+		ExpectedBackground:     RGB(0, 240, 240) | backgroundFlag,
+	},
 }
 
 func TestColors(t *testing.T) {
@@ -177,3 +207,42 @@ func TestColors(t *testing.T) {
 		}
 	}
 }
+
+var parseColorTests = map[string]struct {
+	expectedColor Color
+	expectErr     bool
+}{
+	"red":            {expectedColor: Red},
+	"Bright Red":     {expectedColor: BrightRed},
+	"bright-cyan":    {expectedColor: BrightCyan},
+	"orange":         {expectedColor: Orange},
+	"default":        {expectedColor: Reset},
+	"#00F0F0":        {expectedColor: RGB(0, 240, 240)},
+	"208":            {expectedColor: Orange},
+	"0":              {expectedColor: Palette(0)},
+	"256":            {expectErr: true},
+	"#00f0f":         {expectErr: true},
+	"#gg0000":        {expectErr: true},
+	"":               {expectErr: true},
+	"not a color":    {expectErr: true},
+	"background red": {expectErr: true},
+}
+
+func TestParseColor(t *testing.T) {
+	for input, test := range parseColorTests {
+		color, err := ParseColor(input)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("Unexpectedly no error parsing color '%s'", input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error parsing color '%s': %s", input, err)
+			continue
+		}
+		if color != test.expectedColor {
+			t.Errorf("Unexpected color for input '%s' [expected = %s, actual = %s]", input, test.expectedColor.description(), color.description())
+		}
+	}
+}
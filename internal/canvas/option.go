@@ -48,3 +48,27 @@ type withHeight int
 func (w withHeight) ApplyToCanvas(c *TermCanvas) {
 	c.height = int(w)
 }
+
+// WithoutAltScreen specifies that the canvas should render to the main screen rather than the alternate screen
+// this allows the output to remain visible after exiting
+func WithoutAltScreen() Option {
+	return withoutAltScreen{}
+}
+
+type withoutAltScreen struct{}
+
+func (w withoutAltScreen) ApplyToCanvas(c *TermCanvas) {
+	c.altScreen = false
+}
+
+// WithColorProfile specifies the colors supported by the terminal
+// any other colors are converted to the closest supported color
+func WithColorProfile(p ColorProfile) Option {
+	return withColorProfile(p)
+}
+
+type withColorProfile ColorProfile
+
+func (w withColorProfile) ApplyToCanvas(c *TermCanvas) {
+	c.profile = ColorProfile(w)
+}
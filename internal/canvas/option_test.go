@@ -32,6 +32,36 @@ var optionTests = map[string]struct {
 			checkDefaultHeight,
 		},
 	},
+	"without alt screen": {
+		options: []Option{WithoutAltScreen()},
+		pass: []func(c *TermCanvas) error{
+			checkDefaultBackground,
+			checkDefaultDebugMode,
+			checkDefaultWidth,
+			checkDefaultHeight,
+			func(c *TermCanvas) error {
+				if c.altScreen != false {
+					return fmt.Errorf("unexpected altScreen [expected = %#v, actual = %#v]", false, c.altScreen)
+				}
+				return nil
+			},
+		},
+	},
+	"with color profile": {
+		options: []Option{WithColorProfile(ANSI256)},
+		pass: []func(c *TermCanvas) error{
+			checkDefaultBackground,
+			checkDefaultDebugMode,
+			checkDefaultWidth,
+			checkDefaultHeight,
+			func(c *TermCanvas) error {
+				if c.profile != ANSI256 {
+					return fmt.Errorf("unexpected profile [expected = %s, actual = %s]", ANSI256, c.profile)
+				}
+				return nil
+			},
+		},
+	},
 	"with black background": {
 		options: []Option{
 			WithBackground(Black),
@@ -0,0 +1,184 @@
+package canvas
+
+import (
+	"os"
+	"strings"
+)
+
+// ColorProfile describes which colors the terminal is able to display
+type ColorProfile int
+
+// the available color profiles
+const (
+	// Monochrome doesn't support any colors, so blocks are distinguished using plain characters instead
+	Monochrome ColorProfile = iota
+	// ANSI supports the 16 basic colors
+	ANSI
+	// ANSI256 supports the 256 color palette
+	ANSI256
+	// TrueColor supports 24-bit RGB colors
+	TrueColor
+)
+
+func (p ColorProfile) String() string {
+	profileDescriptions := map[ColorProfile]string{
+		Monochrome: "monochrome",
+		ANSI:       "ansi",
+		ANSI256:    "ansi256",
+		TrueColor:  "truecolor",
+	}
+
+	return profileDescriptions[p]
+}
+
+// DetectColorProfile determines the color profile of the terminal from the environment
+// colors are disabled for dumb terminals, or if NO_COLOR is set: https://no-color.org/
+func DetectColorProfile() ColorProfile {
+	return colorProfileFromEnv(os.Getenv("NO_COLOR"), os.Getenv("COLORTERM"), os.Getenv("TERM"))
+}
+
+func colorProfileFromEnv(noColor, colorTerm, term string) ColorProfile {
+	if noColor != "" || term == "dumb" {
+		return Monochrome
+	}
+
+	switch strings.ToLower(colorTerm) {
+	case "truecolor", "24bit":
+		return TrueColor
+	}
+
+	switch {
+	case strings.HasSuffix(term, "-direct"):
+		return TrueColor
+	case strings.Contains(term, "256color"):
+		return ANSI256
+	default:
+		return ANSI
+	}
+}
+
+// downgrade converts the color to the closest color supported by the profile
+func (c Color) downgrade(p ColorProfile) Color {
+	var converted Color
+	switch {
+	case c.isRGB() && p == ANSI256:
+		converted = rgbToPalette(c.rgb())
+	case c.isRGB() && p == ANSI:
+		converted = rgbToBasic(c.rgb())
+	case c.isPalette() && p == ANSI:
+		converted = paletteToBasic(uint8(c & colorValueMask))
+	default:
+		return c
+	}
+
+	if c.isBackground() {
+		return converted.background()
+	}
+	return converted
+}
+
+// basicColors are the 16 basic colors, in palette order, along with their (xterm) RGB values
+var basicColors = []struct {
+	color   Color
+	r, g, b uint8
+}{
+	{Black, 0, 0, 0},
+	{Red, 205, 0, 0},
+	{Green, 0, 205, 0},
+	{Yellow, 205, 205, 0},
+	{Blue, 0, 0, 238},
+	{Magenta, 205, 0, 205},
+	{Cyan, 0, 205, 205},
+	{White, 229, 229, 229},
+	{BrightBlack, 127, 127, 127},
+	{BrightRed, 255, 0, 0},
+	{BrightGreen, 0, 255, 0},
+	{BrightYellow, 255, 255, 0},
+	{BrightBlue, 92, 92, 255},
+	{BrightMagenta, 255, 0, 255},
+	{BrightCyan, 0, 255, 255},
+	{BrightWhite, 255, 255, 255},
+}
+
+// the levels of each component of the 6x6x6 color cube (palette indices 16-231)
+var cubeLevels = []uint8{0, 95, 135, 175, 215, 255}
+
+// paletteRGB returns the RGB value of a color in the 256 color palette
+func paletteRGB(index uint8) (r, g, b uint8) {
+	switch {
+	case index < 16:
+		c := basicColors[index]
+		return c.r, c.g, c.b
+	case index < 232:
+		i := index - 16
+		return cubeLevels[i/36], cubeLevels[(i/6)%6], cubeLevels[i%6]
+	default:
+		// grayscale ramp
+		gray := 8 + 10*(index-232)
+		return gray, gray, gray
+	}
+}
+
+func paletteToBasic(index uint8) Color {
+	if index < 16 {
+		return basicColors[index].color
+	}
+	return rgbToBasic(paletteRGB(index))
+}
+
+func rgbToBasic(r, g, b uint8) Color {
+	var (
+		closest     Color
+		minDistance = -1
+	)
+	for _, c := range basicColors {
+		if d := colorDistance(r, g, b, c.r, c.g, c.b); minDistance == -1 || d < minDistance {
+			closest, minDistance = c.color, d
+		}
+	}
+	return closest
+}
+
+// rgbToPalette finds the closest color in either the color cube or the grayscale ramp
+// the basic colors (0-15) are skipped since terminals commonly customize them
+func rgbToPalette(r, g, b uint8) Color {
+	cubeIndex := 16 + 36*closestCubeLevel(r) + 6*closestCubeLevel(g) + closestCubeLevel(b)
+	cr, cg, cb := paletteRGB(uint8(cubeIndex))
+
+	average := (int(r) + int(g) + int(b)) / 3
+	grayIndex := 232
+	if average > 8 {
+		grayIndex += (average - 3) / 10
+	}
+	if grayIndex > 255 {
+		grayIndex = 255
+	}
+	gr, gg, gb := paletteRGB(uint8(grayIndex))
+
+	if colorDistance(r, g, b, gr, gg, gb) < colorDistance(r, g, b, cr, cg, cb) {
+		return Palette(uint8(grayIndex))
+	}
+	return Palette(uint8(cubeIndex))
+}
+
+func closestCubeLevel(v uint8) int {
+	closest := 0
+	for i, level := range cubeLevels {
+		if absDiff(v, level) < absDiff(v, cubeLevels[closest]) {
+			closest = i
+		}
+	}
+	return closest
+}
+
+func colorDistance(r1, g1, b1, r2, g2, b2 uint8) int {
+	dr, dg, db := absDiff(r1, r2), absDiff(g1, g2), absDiff(b1, b2)
+	return dr*dr + dg*dg + db*db
+}
+
+func absDiff(a, b uint8) int {
+	if a > b {
+		return int(a - b)
+	}
+	return int(b - a)
+}
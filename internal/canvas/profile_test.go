@@ -0,0 +1,145 @@
+package canvas
+
+import "testing"
+
+var colorProfileTests = map[string]struct {
+	noColor         string
+	colorTerm       string
+	term            string
+	expectedProfile ColorProfile
+}{
+	"no environment": {
+		expectedProfile: ANSI,
+	},
+	"basic term": {
+		term:            "xterm",
+		expectedProfile: ANSI,
+	},
+	"256 color term": {
+		term:            "xterm-256color",
+		expectedProfile: ANSI256,
+	},
+	"screen 256 color term": {
+		term:            "screen-256color",
+		expectedProfile: ANSI256,
+	},
+	"direct color term": {
+		term:            "xterm-direct",
+		expectedProfile: TrueColor,
+	},
+	"truecolor colorterm": {
+		colorTerm:       "truecolor",
+		term:            "xterm-256color",
+		expectedProfile: TrueColor,
+	},
+	"24bit colorterm": {
+		colorTerm:       "24bit",
+		term:            "xterm",
+		expectedProfile: TrueColor,
+	},
+	"unrecognized colorterm": {
+		colorTerm:       "yes",
+		term:            "xterm-256color",
+		expectedProfile: ANSI256,
+	},
+	"dumb term": {
+		term:            "dumb",
+		expectedProfile: Monochrome,
+	},
+	"no color": {
+		noColor:         "1",
+		colorTerm:       "truecolor",
+		term:            "xterm-256color",
+		expectedProfile: Monochrome,
+	},
+}
+
+func TestColorProfileFromEnv(t *testing.T) {
+	for testName, test := range colorProfileTests {
+		profile := colorProfileFromEnv(test.noColor, test.colorTerm, test.term)
+		if profile != test.expectedProfile {
+			t.Errorf("Unexpected profile for test case '%s' [expected = %s, actual = %s]", testName, test.expectedProfile, profile)
+		}
+	}
+}
+
+var downgradeTests = map[string]struct {
+	color    Color
+	profile  ColorProfile
+	expected Color
+}{
+	"basic color truecolor": {
+		color:    Red,
+		profile:  TrueColor,
+		expected: Red,
+	},
+	"basic color ansi": {
+		color:    BrightCyan,
+		profile:  ANSI,
+		expected: BrightCyan,
+	},
+	"rgb truecolor": {
+		color:    RGB(0, 240, 240),
+		profile:  TrueColor,
+		expected: RGB(0, 240, 240),
+	},
+	"rgb ansi256": {
+		color:    RGB(0, 240, 240),
+		profile:  ANSI256,
+		expected: Palette(51),
+	},
+	"rgb ansi256 exact cube color": {
+		color:    RGB(255, 135, 0),
+		profile:  ANSI256,
+		expected: Orange,
+	},
+	"rgb ansi256 gray": {
+		color:    RGB(128, 128, 130),
+		profile:  ANSI256,
+		expected: Palette(244),
+	},
+	"rgb ansi": {
+		color:    RGB(0, 240, 240),
+		profile:  ANSI,
+		expected: BrightCyan,
+	},
+	"rgb background ansi256": {
+		color:    RGB(0, 240, 240).background(),
+		profile:  ANSI256,
+		expected: Palette(51).background(),
+	},
+	"rgb background ansi": {
+		color:    RGB(160, 0, 240).background(),
+		profile:  ANSI,
+		expected: BackgroundMagenta,
+	},
+	"palette ansi256": {
+		color:    Orange,
+		profile:  ANSI256,
+		expected: Orange,
+	},
+	"palette basic color ansi": {
+		color:    Palette(9),
+		profile:  ANSI,
+		expected: BrightRed,
+	},
+	"palette ansi": {
+		color:    Orange,
+		profile:  ANSI,
+		expected: Yellow,
+	},
+	"palette background ansi": {
+		color:    BackgroundOrange,
+		profile:  ANSI,
+		expected: BackgroundYellow,
+	},
+}
+
+func TestDowngrade(t *testing.T) {
+	for testName, test := range downgradeTests {
+		downgraded := test.color.downgrade(test.profile)
+		if downgraded != test.expected {
+			t.Errorf("Unexpected color for test case '%s' [expected = %s, actual = %s]", testName, test.expected.description(), downgraded.description())
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package canvas
+
+import (
+	"sort"
+	"strings"
+)
+
+// sgrState tracks the graphic rendition (colors and other attributes) set by previously written cells
+// cells only set the attributes they care about (e.g. a PipeCell sets its foreground but keeps the current background)
+// so the appearance of a cell depends on the state left by the cells written before it
+type sgrState struct {
+	foreground string
+	background string
+	attributes string // any other attributes (e.g. bold) as a sorted, ';' separated list
+}
+
+// apply updates the state using every SGR sequence (ESC [ ... m) in the provided string
+func (s sgrState) apply(written string) sgrState {
+	for {
+		start := strings.Index(written, "\u001b[")
+		if start == -1 {
+			return s
+		}
+		written = written[start+2:]
+
+		end := strings.IndexByte(written, 'm')
+		if end == -1 {
+			return s
+		}
+		if strings.IndexFunc(written[:end], func(r rune) bool { return r != ';' && (r < '0' || r > '9') }) != -1 {
+			// not an SGR sequence (e.g. a cursor movement)
+			continue
+		}
+		s = s.applyParams(strings.Split(written[:end], ";"))
+		written = written[end+1:]
+	}
+}
+
+func (s sgrState) applyParams(params []string) sgrState {
+	for i := 0; i < len(params); i++ {
+		switch p := params[i]; {
+		case p == "" || p == "0":
+			s = sgrState{}
+		case p == "38" || p == "48":
+			// extended colors: 38;5;n (palette) or 38;2;r;g;b (RGB)
+			n := 0
+			if i+1 < len(params) {
+				switch params[i+1] {
+				case "5":
+					n = 2
+				case "2":
+					n = 4
+				}
+			}
+			if i+n >= len(params) {
+				n = len(params) - 1 - i
+			}
+			color := strings.Join(params[i:i+n+1], ";")
+			if p == "38" {
+				s.foreground = color
+			} else {
+				s.background = color
+			}
+			i += n
+		case isForegroundParam(p):
+			s.foreground = p
+		case isBackgroundParam(p):
+			s.background = p
+		case p == "22":
+			// normal intensity: neither bold nor faint
+			s.attributes = removeAttributes(s.attributes, "1", "2")
+		default:
+			s.attributes = addAttribute(s.attributes, p)
+		}
+	}
+	return s
+}
+
+func isForegroundParam(p string) bool {
+	return len(p) == 2 && (p[0] == '3' || p[0] == '9') && p[1] >= '0' && p[1] <= '9' && p[1] != '8'
+}
+
+func isBackgroundParam(p string) bool {
+	return (len(p) == 2 && p[0] == '4' && p[1] >= '0' && p[1] <= '9' && p[1] != '8') ||
+		(len(p) == 3 && p[:2] == "10" && p[2] >= '0' && p[2] <= '7')
+}
+
+func addAttribute(attributes, p string) string {
+	if attributes == "" {
+		return p
+	}
+	existing := strings.Split(attributes, ";")
+	for _, a := range existing {
+		if a == p {
+			return attributes
+		}
+	}
+	existing = append(existing, p)
+	sort.Strings(existing)
+	return strings.Join(existing, ";")
+}
+
+func removeAttributes(attributes string, removed ...string) string {
+	kept := []string{}
+	for _, a := range strings.Split(attributes, ";") {
+		keep := a != ""
+		for _, r := range removed {
+			if a == r {
+				keep = false
+			}
+		}
+		if keep {
+			kept = append(kept, a)
+		}
+	}
+	return strings.Join(kept, ";")
+}
+
+// String is the sequence which sets the terminal to this state, regardless of its current state
+func (s sgrState) String() string {
+	var b strings.Builder
+	b.WriteString("\u001b[0")
+	for _, part := range []string{s.attributes, s.foreground, s.background} {
+		if part != "" {
+			b.WriteByte(';')
+			b.WriteString(part)
+		}
+	}
+	b.WriteByte('m')
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package canvas
+
+import "testing"
+
+var sgrStateTests = map[string]struct {
+	initial        sgrState
+	written        string
+	expectedState  sgrState
+	expectedString string
+}{
+	"no formatting": {
+		written:        "abc",
+		expectedState:  sgrState{},
+		expectedString: "\u001b[0m",
+	},
+	"foreground": {
+		written:        Red.decorate(block),
+		expectedState:  sgrState{foreground: "31"},
+		expectedString: "\u001b[0;31m",
+	},
+	"background then foreground": {
+		written:        Blue.background().decorate(Red.decorate(block)),
+		expectedState:  sgrState{foreground: "31", background: "44"},
+		expectedString: "\u001b[0;31;44m",
+	},
+	"bright foreground keeps background": {
+		initial:        sgrState{foreground: "31", background: "44"},
+		written:        BrightGreen.decorate(block),
+		expectedState:  sgrState{foreground: "32", background: "44", attributes: "1"},
+		expectedString: "\u001b[0;1;32;44m",
+	},
+	"reset": {
+		initial:        sgrState{foreground: "31", background: "44", attributes: "1"},
+		written:        Reset.decorate(" "),
+		expectedState:  sgrState{},
+		expectedString: "\u001b[0m",
+	},
+	"palette colors": {
+		written:        BackgroundOrange.decorate(Orange.decorate(block)),
+		expectedState:  sgrState{foreground: "38;5;208", background: "48;5;208"},
+		expectedString: "\u001b[0;38;5;208;48;5;208m",
+	},
+	"rgb color": {
+		written:        "\u001b[38;2;255;128;0m" + block,
+		expectedState:  sgrState{foreground: "38;2;255;128;0"},
+		expectedString: "\u001b[0;38;2;255;128;0m",
+	},
+	"multiple attributes": {
+		written:        "\u001b[7m\u001b[1;2m",
+		expectedState:  sgrState{attributes: "1;2;7"},
+		expectedString: "\u001b[0;1;2;7m",
+	},
+	"normal intensity clears bold and faint": {
+		initial:        sgrState{foreground: "31", attributes: "1;2;7"},
+		written:        faintControl + block + normalIntensityControl,
+		expectedState:  sgrState{foreground: "31", attributes: "7"},
+		expectedString: "\u001b[0;7;31m",
+	},
+	"cursor movement ignored": {
+		initial:        sgrState{foreground: "31"},
+		written:        "\u001b[1;1H" + block,
+		expectedState:  sgrState{foreground: "31"},
+		expectedString: "\u001b[0;31m",
+	},
+}
+
+func TestSGRState(t *testing.T) {
+	for testName, test := range sgrStateTests {
+		state := test.initial.apply(test.written)
+		if state != test.expectedState {
+			t.Errorf("Unexpected state for test case '%s' [expected = %#v, actual = %#v]", testName, test.expectedState, state)
+		}
+		if state.String() != test.expectedString {
+			t.Errorf("Unexpected string for test case '%s' [expected = %#v, actual = %#v]", testName, test.expectedString, state.String())
+		}
+	}
+}
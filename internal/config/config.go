@@ -0,0 +1,308 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ShawnROGrady/gotris/internal/canvas"
+	"github.com/ShawnROGrady/gotris/internal/game"
+	"github.com/ShawnROGrady/gotris/internal/game/board"
+	"github.com/ShawnROGrady/gotris/internal/game/tetrimino"
+	"github.com/ShawnROGrady/gotris/internal/theme"
+)
+
+const fileName = "config.json"
+
+// Config represents the game options which can be specified in the configuration file
+// the keys match the names of the equivalent command line flags
+type Config struct {
+	Schemes        []string `json:"scheme,omitempty"`
+	Mode           string   `json:"mode"`
+	Difficulty     string   `json:"difficulty"`
+	Width          int      `json:"width,omitempty"`
+	Height         int      `json:"height,omitempty"`
+	DisableGhost   bool     `json:"disable-ghost"`
+	DisableSide    bool     `json:"disable-side"`
+	Panels         []string `json:"panels,omitempty"`  // the panels of the side bar in order, the default panels are displayed if not specified
+	Sidebar        string   `json:"sidebar,omitempty"` // the side of the board to display the side bar on
+	LightMode      bool     `json:"light-mode"`
+	LowContrast    bool     `json:"low-contrast"`
+	Theme          string   `json:"theme,omitempty"`       // a built in theme or the path to a theme file, takes precedence over light-mode and low-contrast
+	GhostStyle     string   `json:"ghost-style,omitempty"` // overrides the ghost style of the theme
+	Monochrome     bool     `json:"monochrome"`
+	HalfBlocks     bool     `json:"half-blocks"`
+	PartialLockOut bool     `json:"partial-lock-out"`
+	MaxFPS         int      `json:"max-fps"`
+
+	// CustomSchemes maps the name of each user defined control scheme to its key bindings
+	// see game.CustomScheme for the supported key and action names
+	CustomSchemes map[string]map[string]string `json:"custom-schemes,omitempty"`
+}
+
+// Default returns the configuration used when no file is present
+func Default() Config {
+	return Config{
+		Mode:       game.MarathonMode,
+		Difficulty: game.BeginnerDifficulty,
+		MaxFPS:     game.DefaultMaxFPS,
+	}
+}
+
+// DefaultPath returns the location of the configuration file
+// this is $XDG_CONFIG_HOME/gotris/config.json, falling back to ~/.config/gotris/config.json
+func DefaultPath() (string, error) {
+	return defaultPath(os.Getenv, os.UserHomeDir)
+}
+
+func defaultPath(getenv func(string) string, homeDir func() (string, error)) (string, error) {
+	configHome := getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := homeDir()
+		if err != nil {
+			return "", err
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configHome, "gotris", fileName), nil
+}
+
+// Load reads the configuration from the specified file
+// any options missing from the file use their default value, and the default configuration is returned if the file doesn't exist
+func Load(path string) (Config, error) {
+	c := Default()
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return c, err
+	}
+
+	if err := json.Unmarshal(contents, &c); err != nil {
+		return c, fmt.Errorf("error parsing config from '%s': %s", path, err)
+	}
+	return c, nil
+}
+
+// Write writes the configuration in the same format it is loaded
+func (c Config) Write(w io.Writer) error {
+	contents, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(contents, '\n'))
+	return err
+}
+
+// SchemeFromName retrieves the built in or custom scheme associated with the specified name
+func (c Config) SchemeFromName(name string) (game.ControlScheme, error) {
+	if bindings, ok := c.CustomSchemes[name]; ok {
+		return game.CustomScheme(name, bindings)
+	}
+	return game.SchemeFromName(name)
+}
+
+// AvailableSchemes lists the built in schemes followed by any custom schemes
+func (c Config) AvailableSchemes() ([]game.ControlScheme, error) {
+	schemes := game.AvailableSchemes()
+
+	// sort to allow consistent ordering
+	names := []string{}
+	for name := range c.CustomSchemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		s, err := game.CustomScheme(name, c.CustomSchemes[name])
+		if err != nil {
+			return nil, err
+		}
+		schemes = append(schemes, s)
+	}
+	return schemes, nil
+}
+
+// ControlSchemes returns the configured control schemes
+// nil is returned if no schemes are specified
+func (c Config) ControlSchemes() (game.ControlSchemes, error) {
+	var schemes game.ControlSchemes
+	for _, name := range c.Schemes {
+		s, err := c.SchemeFromName(name)
+		if err != nil {
+			return nil, err
+		}
+		schemes = append(schemes, s)
+	}
+	if err := schemes.Validate(); err != nil {
+		return nil, err
+	}
+	return schemes, nil
+}
+
+// ColorProfile returns the color profile to render with, given the profile detected from the terminal
+func (c Config) ColorProfile(detected canvas.ColorProfile) canvas.ColorProfile {
+	if c.Monochrome {
+		return canvas.Monochrome
+	}
+	return detected
+}
+
+// BoardSize returns the configured width and height of the board
+// the default is used for any dimension which isn't specified
+func (c Config) BoardSize() (int, int) {
+	width, height := c.Width, c.Height
+	if width == 0 {
+		width = board.DefaultWidth
+	}
+	if height == 0 {
+		height = board.DefaultHeight
+	}
+	return width, height
+}
+
+// Options converts the configuration to the options used to create a new game
+func (c Config) Options() ([]game.Option, error) {
+	opts := []game.Option{}
+
+	initLevel, err := game.LevelFromDifficulty(c.Difficulty)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, game.WithInitialLevel(initLevel))
+
+	mode, err := game.ModeFromName(c.Mode)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, game.WithMode(mode))
+
+	width, height := c.BoardSize()
+	// every piece must fit on the board
+	if width < tetrimino.MaxWidth || height < tetrimino.MaxHeight {
+		return nil, fmt.Errorf("invalid board size: %dx%d (the minimum is %dx%d)", width, height, tetrimino.MaxWidth, tetrimino.MaxHeight)
+	}
+	opts = append(opts, game.WithBoardSize(width, height))
+
+	t, err := c.SelectedTheme()
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, game.WithTheme(t))
+
+	if c.DisableGhost {
+		opts = append(opts, game.WithoutGhost())
+	}
+
+	if c.DisableSide {
+		opts = append(opts, game.WithoutSide())
+	}
+
+	if c.Panels != nil {
+		panels := []game.Panel{}
+		for _, name := range c.Panels {
+			p, err := game.PanelFromName(name)
+			if err != nil {
+				return nil, err
+			}
+			panels = append(panels, p)
+		}
+		opts = append(opts, game.WithPanels(panels...))
+	}
+
+	if c.Sidebar != "" {
+		position, err := game.SidebarPositionFromName(c.Sidebar)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, game.WithSidebarPosition(position))
+	}
+
+	if c.HalfBlocks {
+		opts = append(opts, game.WithHalfBlocks())
+	}
+
+	if c.PartialLockOut {
+		opts = append(opts, game.WithPartialLockOut())
+	}
+
+	if c.MaxFPS < 0 {
+		return nil, fmt.Errorf("invalid max fps: %d", c.MaxFPS)
+	}
+	opts = append(opts, game.WithMaxFPS(c.MaxFPS))
+
+	// validate custom schemes even if they aren't selected
+	if _, err := c.AvailableSchemes(); err != nil {
+		return nil, err
+	}
+	scheme, err := c.ControlSchemes()
+	if err != nil {
+		return nil, err
+	}
+	if scheme == nil {
+		// default to home row if no scheme provided
+		scheme = game.ControlSchemes{game.HomeRow()}
+	}
+	opts = append(opts, game.WithControlScheme(scheme))
+
+	return opts, nil
+}
+
+// SelectedTheme returns the configured theme, with the configured ghost style (if any)
+// if no theme is specified the theme is chosen based on the light-mode and low-contrast options
+func (c Config) SelectedTheme() (theme.Theme, error) {
+	t, err := c.baseTheme()
+	if err != nil {
+		return theme.Theme{}, err
+	}
+
+	if c.GhostStyle != "" {
+		if t.Ghost, err = canvas.GhostStyleFromName(c.GhostStyle); err != nil {
+			return theme.Theme{}, err
+		}
+	}
+	return t, nil
+}
+
+func (c Config) baseTheme() (theme.Theme, error) {
+	if c.Theme != "" {
+		return theme.Lookup(c.Theme)
+	}
+
+	switch {
+	case c.LightMode && c.LowContrast:
+		return theme.LightLowContrast(), nil
+	case c.LightMode:
+		return theme.Light(), nil
+	case c.LowContrast:
+		return theme.LowContrast(), nil
+	default:
+		return theme.Classic(), nil
+	}
+}
+
+// displayTheme is the theme used outside of the game (e.g. menus)
+// an invalid theme is reported when the options are validated, so the classic theme is used instead
+func (c Config) displayTheme() theme.Theme {
+	t, err := c.SelectedTheme()
+	if err != nil {
+		return theme.Classic()
+	}
+	return t
+}
+
+// TextColor is the color used for text outside of the game (e.g. menus)
+func (c Config) TextColor() canvas.Color {
+	return c.displayTheme().Text
+}
+
+// BoxStyle is the style used for boxes outside of the game (e.g. menus)
+func (c Config) BoxStyle() canvas.BoxStyle {
+	return c.displayTheme().BoxStyle()
+}
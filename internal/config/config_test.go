@@ -0,0 +1,417 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ShawnROGrady/gotris/internal/canvas"
+	"github.com/ShawnROGrady/gotris/internal/theme"
+)
+
+var loadTests = map[string]struct {
+	contents    string
+	missing     bool
+	expectedErr bool
+	expected    Config
+}{
+	"missing file": {
+		missing:  true,
+		expected: Default(),
+	},
+	"partial file": {
+		contents: `{"difficulty": "pro", "disable-ghost": true, "scheme": ["arrow-keys"]}`,
+		expected: Config{
+			Schemes:      []string{"arrow-keys"},
+			Mode:         "marathon",
+			Difficulty:   "pro",
+			DisableGhost: true,
+			MaxFPS:       60,
+		},
+	},
+	"full file": {
+		contents: `{
+			"scheme": ["home-row", "standard"],
+			"mode": "sprint",
+			"difficulty": "expert",
+			"width": 12,
+			"height": 24,
+			"disable-ghost": true,
+			"disable-side": true,
+			"panels": ["controls", "next"],
+			"sidebar": "left",
+			"light-mode": true,
+			"low-contrast": true,
+			"monochrome": true,
+			"half-blocks": true,
+			"partial-lock-out": true,
+			"max-fps": 30,
+			"theme": "solarized",
+			"ghost-style": "dim",
+			"custom-schemes": {"vim": {"h": "move-left", "ctrl+r": "rotate-right"}}
+		}`,
+		expected: Config{
+			Schemes:        []string{"home-row", "standard"},
+			Mode:           "sprint",
+			Difficulty:     "expert",
+			Width:          12,
+			Height:         24,
+			DisableGhost:   true,
+			DisableSide:    true,
+			Panels:         []string{"controls", "next"},
+			Sidebar:        "left",
+			LightMode:      true,
+			LowContrast:    true,
+			Monochrome:     true,
+			HalfBlocks:     true,
+			PartialLockOut: true,
+			MaxFPS:         30,
+			Theme:          "solarized",
+			GhostStyle:     "dim",
+			CustomSchemes:  map[string]map[string]string{"vim": {"h": "move-left", "ctrl+r": "rotate-right"}},
+		},
+	},
+	"invalid json": {
+		contents:    `{"difficulty": `,
+		expectedErr: true,
+	},
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotris")
+	if err != nil {
+		t.Fatalf("Unexpected error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for testName, test := range loadTests {
+		path := filepath.Join(dir, testName+".json")
+		if !test.missing {
+			if err := ioutil.WriteFile(path, []byte(test.contents), 0644); err != nil {
+				t.Fatalf("Unexpected error writing config for test case '%s': %s", testName, err)
+			}
+		}
+
+		c, err := Load(path)
+		if err != nil {
+			if !test.expectedErr {
+				t.Errorf("Unexpected error for test case '%s': %s", testName, err)
+			}
+			continue
+		}
+		if test.expectedErr {
+			t.Errorf("Unexpectedly no error for test case '%s'", testName)
+		}
+		if !reflect.DeepEqual(c, test.expected) {
+			t.Errorf("Unexpected config for test case '%s' [expected = %+v, actual = %+v]", testName, test.expected, c)
+		}
+	}
+}
+
+func TestWriteLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotris")
+	if err != nil {
+		t.Fatalf("Unexpected error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := Config{
+		Schemes:     []string{"standard"},
+		Mode:        "ultra",
+		Difficulty:  "novice",
+		Width:       8,
+		Height:      16,
+		DisableSide: true,
+		LowContrast: true,
+	}
+
+	var b bytes.Buffer
+	if err := c.Write(&b); err != nil {
+		t.Fatalf("Unexpected error writing config: %s", err)
+	}
+
+	path := filepath.Join(dir, fileName)
+	if err := ioutil.WriteFile(path, b.Bytes(), 0644); err != nil {
+		t.Fatalf("Unexpected error writing config file: %s", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Unexpected error loading written config: %s", err)
+	}
+	if !reflect.DeepEqual(c, loaded) {
+		t.Errorf("Unexpected config after writing then loading [expected = %+v, actual = %+v]", c, loaded)
+	}
+}
+
+var optionsTests = map[string]struct {
+	config      Config
+	expectedErr bool
+}{
+	"default": {
+		config: Default(),
+	},
+	"all options": {
+		config: Config{
+			Schemes:        []string{"home-row", "arrow-keys"},
+			Mode:           "sprint",
+			Difficulty:     "pro",
+			Width:          12,
+			Height:         24,
+			DisableGhost:   true,
+			DisableSide:    true,
+			Panels:         []string{"score"},
+			Sidebar:        "right",
+			LightMode:      true,
+			LowContrast:    true,
+			Monochrome:     true,
+			HalfBlocks:     true,
+			PartialLockOut: true,
+		},
+	},
+	"unrecognized difficulty": {
+		config:      Config{Mode: "marathon", Difficulty: "impossible"},
+		expectedErr: true,
+	},
+	"unrecognized mode": {
+		config:      Config{Mode: "zen", Difficulty: "beginner"},
+		expectedErr: true,
+	},
+	"unrecognized scheme": {
+		config:      Config{Mode: "marathon", Difficulty: "beginner", Schemes: []string{"wasd"}},
+		expectedErr: true,
+	},
+	"custom scheme": {
+		config: Config{
+			Mode:          "marathon",
+			Difficulty:    "beginner",
+			Schemes:       []string{"vim", "arrow-keys"},
+			CustomSchemes: map[string]map[string]string{"vim": {"h": "move-left", "l": "move-right"}},
+		},
+	},
+	"invalid custom scheme not selected": {
+		config: Config{
+			Mode:          "marathon",
+			Difficulty:    "beginner",
+			CustomSchemes: map[string]map[string]string{"vim": {"h": "hold"}},
+		},
+		expectedErr: true,
+	},
+	"conflicting schemes": {
+		config: Config{
+			Mode:          "marathon",
+			Difficulty:    "beginner",
+			Schemes:       []string{"home-row", "vim"},
+			CustomSchemes: map[string]map[string]string{"vim": {"h": "move-right"}},
+		},
+		expectedErr: true,
+	},
+	"negative max fps": {
+		config:      Config{Mode: "marathon", Difficulty: "beginner", MaxFPS: -1},
+		expectedErr: true,
+	},
+	"built in theme": {
+		config: Config{Mode: "marathon", Difficulty: "beginner", Theme: "guideline"},
+	},
+	"unknown theme": {
+		config:      Config{Mode: "marathon", Difficulty: "beginner", Theme: "unknown"},
+		expectedErr: true,
+	},
+	"unknown panel": {
+		config:      Config{Mode: "marathon", Difficulty: "beginner", Panels: []string{"hold"}},
+		expectedErr: true,
+	},
+	"unknown sidebar position": {
+		config:      Config{Mode: "marathon", Difficulty: "beginner", Sidebar: "top"},
+		expectedErr: true,
+	},
+	"unknown ghost style": {
+		config:      Config{Mode: "marathon", Difficulty: "beginner", GhostStyle: "invisible"},
+		expectedErr: true,
+	},
+	"missing theme file": {
+		config:      Config{Mode: "marathon", Difficulty: "beginner", Theme: "/does/not/exist.json"},
+		expectedErr: true,
+	},
+	"negative width": {
+		config:      Config{Mode: "marathon", Difficulty: "beginner", Width: -1, Height: 20},
+		expectedErr: true,
+	},
+	"board too small": {
+		config:      Config{Mode: "marathon", Difficulty: "beginner", Width: 3, Height: 3},
+		expectedErr: true,
+	},
+	"board too narrow": {
+		config:      Config{Mode: "marathon", Difficulty: "beginner", Width: 1, Height: 30},
+		expectedErr: true,
+	},
+	"only width": {
+		config: Config{Mode: "marathon", Difficulty: "beginner", Width: 12},
+	},
+}
+
+var boardSizeTests = map[string]struct {
+	config         Config
+	expectedWidth  int
+	expectedHeight int
+}{
+	"default": {
+		expectedWidth:  10,
+		expectedHeight: 20,
+	},
+	"both dimensions": {
+		config:         Config{Width: 12, Height: 24},
+		expectedWidth:  12,
+		expectedHeight: 24,
+	},
+	"only width": {
+		config:         Config{Width: 12},
+		expectedWidth:  12,
+		expectedHeight: 20,
+	},
+	"only height": {
+		config:         Config{Height: 30},
+		expectedWidth:  10,
+		expectedHeight: 30,
+	},
+}
+
+func TestBoardSize(t *testing.T) {
+	for testName, test := range boardSizeTests {
+		width, height := test.config.BoardSize()
+		if width != test.expectedWidth || height != test.expectedHeight {
+			t.Errorf("Unexpected board size for test case '%s' [expected = %dx%d, actual = %dx%d]", testName, test.expectedWidth, test.expectedHeight, width, height)
+		}
+	}
+}
+
+func TestOptions(t *testing.T) {
+	for testName, test := range optionsTests {
+		_, err := test.config.Options()
+		if err != nil {
+			if !test.expectedErr {
+				t.Errorf("Unexpected error for test case '%s': %s", testName, err)
+			}
+			continue
+		}
+		if test.expectedErr {
+			t.Errorf("Unexpectedly no error for test case '%s'", testName)
+		}
+	}
+}
+
+var defaultPathTests = map[string]struct {
+	env          map[string]string
+	homeDir      string
+	homeDirErr   error
+	expectedPath string
+	expectedErr  bool
+}{
+	"XDG_CONFIG_HOME set": {
+		env:          map[string]string{"XDG_CONFIG_HOME": "/config"},
+		homeDir:      "/home/user",
+		expectedPath: "/config/gotris/config.json",
+	},
+	"XDG_CONFIG_HOME not set": {
+		homeDir:      "/home/user",
+		expectedPath: "/home/user/.config/gotris/config.json",
+	},
+	"no home directory": {
+		homeDirErr:  errors.New("no home"),
+		expectedErr: true,
+	},
+}
+
+func TestDefaultPath(t *testing.T) {
+	for testName, test := range defaultPathTests {
+		var (
+			getenv  = func(key string) string { return test.env[key] }
+			homeDir = func() (string, error) { return test.homeDir, test.homeDirErr }
+		)
+
+		path, err := defaultPath(getenv, homeDir)
+		if err != nil {
+			if !test.expectedErr {
+				t.Errorf("Unexpected error for test case '%s': %s", testName, err)
+			}
+			continue
+		}
+		if test.expectedErr {
+			t.Errorf("Unexpectedly no error for test case '%s'", testName)
+		}
+		if path != test.expectedPath {
+			t.Errorf("Unexpected path for test case '%s' [expected = %s, actual = %s]", testName, test.expectedPath, path)
+		}
+	}
+}
+
+var selectedThemeTests = map[string]struct {
+	config        Config
+	expectedTheme string
+	expectedGhost canvas.GhostStyle // defaults to the ghost style of the theme
+}{
+	"default": {
+		expectedTheme: "classic",
+	},
+	"light mode": {
+		config:        Config{LightMode: true},
+		expectedTheme: "light",
+	},
+	"low contrast": {
+		config:        Config{LowContrast: true},
+		expectedTheme: "low-contrast",
+	},
+	"light mode low contrast": {
+		config:        Config{LightMode: true, LowContrast: true},
+		expectedTheme: "light-low-contrast",
+	},
+	"theme takes precedence": {
+		config:        Config{LightMode: true, Theme: "solarized"},
+		expectedTheme: "solarized",
+	},
+	"ghost style": {
+		config:        Config{Theme: "solarized", GhostStyle: "outline"},
+		expectedTheme: "solarized",
+		expectedGhost: canvas.OutlineGhost,
+	},
+	"custom ghost glyph": {
+		config:        Config{GhostStyle: "*"},
+		expectedTheme: "classic",
+		expectedGhost: "*",
+	},
+}
+
+func TestSelectedTheme(t *testing.T) {
+	for testName, test := range selectedThemeTests {
+		selected, err := test.config.SelectedTheme()
+		if err != nil {
+			t.Errorf("Unexpected error for test case '%s': %s", testName, err)
+			continue
+		}
+		if selected.Name != test.expectedTheme {
+			t.Errorf("Unexpected theme for test case '%s' [expected = %s, actual = %s]", testName, test.expectedTheme, selected.Name)
+		}
+
+		expectedGhost := test.expectedGhost
+		if expectedGhost == "" {
+			base, _ := theme.FromName(test.expectedTheme)
+			expectedGhost = base.Ghost
+		}
+		if selected.Ghost != expectedGhost {
+			t.Errorf("Unexpected ghost style for test case '%s' [expected = %s, actual = %s]", testName, expectedGhost, selected.Ghost)
+		}
+	}
+}
+
+func TestColorProfile(t *testing.T) {
+	if profile := (Config{}).ColorProfile(canvas.ANSI256); profile != canvas.ANSI256 {
+		t.Errorf("Unexpected color profile without monochrome [expected = %s, actual = %s]", canvas.ANSI256, profile)
+	}
+	if profile := (Config{Monochrome: true}).ColorProfile(canvas.TrueColor); profile != canvas.Monochrome {
+		t.Errorf("Unexpected color profile with monochrome [expected = %s, actual = %s]", canvas.Monochrome, profile)
+	}
+}
@@ -7,6 +7,8 @@ import (
 // Defaults for board
 const (
 	DefaultWidthScale = 2
+	DefaultWidth      = canvas.DefaultWidth / DefaultWidthScale
+	DefaultHeight     = canvas.DefaultHeight
 	defaultHiddenRows = 4
 )
 
@@ -16,6 +18,7 @@ type Board struct {
 	Blocks     [][]*Block
 	hiddenRows int
 	widthScale int
+	ghostStyle canvas.GhostStyle
 	width      int
 	height     int
 }
@@ -28,8 +31,8 @@ func New(opts ...Option) *Board {
 		background: canvas.DefaultBackground,
 		hiddenRows: defaultHiddenRows,
 		widthScale: DefaultWidthScale,
-		width:      canvas.DefaultWidth / DefaultWidthScale,
-		height:     canvas.DefaultHeight,
+		width:      DefaultWidth,
+		height:     DefaultHeight,
 	}
 
 	for i := range opts {
@@ -60,12 +63,14 @@ func (b *Board) HiddenRows() int {
 type Block struct {
 	Color       canvas.Color
 	Transparent bool
+	Label       string // identifies the block when colors aren't available
 }
 
 func (b *Block) cell() *canvas.BlockCell {
 	return &canvas.BlockCell{
 		Color:       b.Color,
 		Transparent: b.Transparent,
+		Label:       b.Label,
 	}
 }
 
@@ -115,6 +120,7 @@ func (b *Board) Cells() [][]canvas.Cell {
 			}
 			blockCell := block.cell()
 			blockCell.Background = b.background
+			blockCell.Ghost = b.ghostStyle
 			for i := 0; i < b.widthScale; i++ {
 				row = append(row, blockCell)
 			}
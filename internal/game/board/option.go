@@ -61,3 +61,14 @@ type withHeight int
 func (w withHeight) ApplyToBoard(b *Board) {
 	b.height = int(w)
 }
+
+// WithGhostStyle returns an option that specifies how transparent blocks (e.g. the ghost piece) are drawn
+func WithGhostStyle(style canvas.GhostStyle) Option {
+	return withGhostStyle(style)
+}
+
+type withGhostStyle canvas.GhostStyle
+
+func (w withGhostStyle) ApplyToBoard(b *Board) {
+	b.ghostStyle = canvas.GhostStyle(w)
+}
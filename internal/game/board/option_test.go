@@ -20,6 +20,24 @@ var optionTests = map[string]struct {
 			checkDefaultHeight,
 		},
 	},
+	"with dark ghost style": {
+		options: []Option{
+			WithGhostStyle(canvas.DarkGhost),
+		},
+		pass: []func(b *Board) error{
+			checkDefaultBackground,
+			checkDefaultHiddenRows,
+			checkDefaultWidthScale,
+			checkDefaultWidth,
+			checkDefaultHeight,
+			func(b *Board) error {
+				if b.ghostStyle != canvas.DarkGhost {
+					return fmt.Errorf("unexpected ghostStyle [expected = %s, actual = %s]", canvas.DarkGhost, b.ghostStyle)
+				}
+				return nil
+			},
+		},
+	},
 	"with black background": {
 		options: []Option{
 			WithBackground(canvas.Black),
@@ -1,45 +1,123 @@
 package game
 
 import (
+	"context"
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ShawnROGrady/gotris/internal/canvas"
 )
 
-// gCanvas wraps a standard canvas
+// DefaultMaxFPS is the default maximum number of frames rendered per second
+const DefaultMaxFPS = 60
+
+// gCanvas wraps a standard canvas, rendering in the background so updating the game never waits on the terminal
+// only the most recent frame is rendered, so frames updated faster than they can be rendered (or faster than the max FPS) are dropped
 type gCanvas struct {
 	canvas    canvas.Canvas
-	newCells  chan [][]canvas.Cell
-	mut       *sync.Mutex
+	mut       *sync.Mutex // guards latest, dropped and renderErr
+	renderMut *sync.Mutex // held while rendering, so the underlying canvas is only used by one goroutine
+	latest    [][]canvas.Cell
+	newFrame  chan struct{}
+	frameTime time.Duration
+	dropped   int
 	renderErr error
+	debugMode bool
+}
+
+func newGCanvas(c canvas.Canvas, maxFPS int, debugMode bool) *gCanvas {
+	var frameTime time.Duration
+	if maxFPS > 0 {
+		frameTime = time.Second / time.Duration(maxFPS)
+	}
+	return &gCanvas{
+		canvas:    c,
+		mut:       &sync.Mutex{},
+		renderMut: &sync.Mutex{},
+		newFrame:  make(chan struct{}, 1),
+		frameTime: frameTime,
+		debugMode: debugMode,
+	}
 }
 
-func (g *gCanvas) run(done <-chan bool) {
-	go func() {
-		for {
+// run renders frames as they're updated until the context is cancelled
+func (g *gCanvas) run(ctx context.Context) {
+	var lastRender time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-g.newFrame:
+		}
+
+		// wait for the rest of the frame time, rendering whichever frame is the latest at that point
+		if wait := g.frameTime - time.Since(lastRender); wait > 0 {
 			select {
-			case <-done:
+			case <-ctx.Done():
 				return
-			case cells := <-g.newCells:
-				g.mut.Lock()
-				g.canvas.UpdateCells(cells)
-				if err := g.canvas.Render(); err != nil {
-					g.renderErr = err
-				}
-				g.mut.Unlock()
+			case <-time.After(wait):
 			}
 		}
-	}()
+
+		if err := g.flush(); err != nil {
+			g.mut.Lock()
+			g.renderErr = err
+			g.mut.Unlock()
+		}
+		lastRender = time.Now()
+	}
+}
+
+// flush renders the latest frame immediately, if it hasn't already been rendered
+func (g *gCanvas) flush() error {
+	g.renderMut.Lock()
+	defer g.renderMut.Unlock()
+
+	g.mut.Lock()
+	cells, dropped := g.latest, g.dropped
+	g.latest, g.dropped = nil, 0
+	g.mut.Unlock()
+
+	if cells == nil {
+		return nil
+	}
+	if g.debugMode && dropped != 0 {
+		fmt.Printf("Dropped frames: %d\n", dropped)
+	}
+
+	g.canvas.UpdateCells(cells)
+	return g.canvas.Render()
 }
 
 func (g *gCanvas) Init() error {
+	g.renderMut.Lock()
+	defer g.renderMut.Unlock()
 	return g.canvas.Init()
 }
 
+func (g *gCanvas) Close() error {
+	return g.canvas.Close()
+}
+
+// UpdateCells replaces any frame which hasn't been rendered yet
 func (g *gCanvas) UpdateCells(newCells [][]canvas.Cell) {
-	g.newCells <- newCells
+	g.mut.Lock()
+	if g.latest != nil {
+		g.dropped++
+	}
+	g.latest = newCells
+	g.mut.Unlock()
+
+	select {
+	case g.newFrame <- struct{}{}:
+	default:
+		// the renderer has already been notified
+	}
 }
 
+// Render reports any error from rendering previous frames
+// the frame itself is rendered in the background
 func (g *gCanvas) Render() error {
 	g.mut.Lock()
 	defer g.mut.Unlock()
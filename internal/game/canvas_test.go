@@ -0,0 +1,144 @@
+package game
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ShawnROGrady/gotris/internal/canvas"
+)
+
+// countingCanvas records each rendered frame, optionally blocking renders until released
+type countingCanvas struct {
+	mut      sync.Mutex
+	current  [][]canvas.Cell
+	rendered [][][]canvas.Cell
+	release  chan bool
+}
+
+func (c *countingCanvas) Init() error  { return nil }
+func (c *countingCanvas) Close() error { return nil }
+func (c *countingCanvas) UpdateCells(newCells [][]canvas.Cell) {
+	c.current = newCells
+}
+func (c *countingCanvas) Render() error {
+	if c.release != nil {
+		<-c.release
+	}
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.rendered = append(c.rendered, c.current)
+	return nil
+}
+
+func (c *countingCanvas) frames() [][][]canvas.Cell {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.rendered
+}
+
+func testFrame(text string) [][]canvas.Cell {
+	return canvas.CellsFromString(text, canvas.White)
+}
+
+func frameText(frame [][]canvas.Cell) string {
+	return frame[0][0].(*canvas.TextCell).Text
+}
+
+func TestGCanvasLatestFrameWins(t *testing.T) {
+	var (
+		c           = &countingCanvas{release: make(chan bool)}
+		g           = newGCanvas(c, 0, false)
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+	defer cancel()
+	go g.run(ctx)
+
+	// the first frame blocks the renderer, so the following frames are coalesced
+	g.UpdateCells(testFrame("a"))
+	time.Sleep(10 * time.Millisecond)
+
+	updated := make(chan bool)
+	go func() {
+		for _, text := range []string{"b", "c", "d"} {
+			g.UpdateCells(testFrame(text))
+		}
+		close(updated)
+	}()
+
+	select {
+	case <-updated:
+	case <-time.After(100 * time.Millisecond):
+		t.Fatalf("UpdateCells unexpectedly blocked while rendering")
+	}
+
+	close(c.release)
+	time.Sleep(20 * time.Millisecond)
+
+	frames := c.frames()
+	if len(frames) != 2 {
+		t.Fatalf("Unexpected number of rendered frames [expected = %d, actual = %d]", 2, len(frames))
+	}
+	if frameText(frames[0]) != "a" || frameText(frames[1]) != "d" {
+		t.Errorf("Unexpected rendered frames [expected = a, d, actual = %s, %s]", frameText(frames[0]), frameText(frames[1]))
+	}
+}
+
+func TestGCanvasMaxFPS(t *testing.T) {
+	var (
+		c           = &countingCanvas{}
+		g           = newGCanvas(c, 10, false)
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+	defer cancel()
+	go g.run(ctx)
+
+	// 100ms per frame, so only the first frame and the latest frame after it should be rendered
+	stop := time.After(150 * time.Millisecond)
+	for i := 0; ; i++ {
+		select {
+		case <-stop:
+			frames := c.frames()
+			if len(frames) != 2 {
+				t.Errorf("Unexpected number of rendered frames [expected = %d, actual = %d]", 2, len(frames))
+			}
+			return
+		default:
+			g.UpdateCells(testFrame(string(rune('a' + i%26))))
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+func TestGCanvasFlush(t *testing.T) {
+	var (
+		c           = &countingCanvas{}
+		g           = newGCanvas(c, 1, false)
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+	defer cancel()
+	go g.run(ctx)
+
+	g.UpdateCells(testFrame("a"))
+	time.Sleep(10 * time.Millisecond)
+
+	// the frame rate would otherwise delay this frame for a second
+	g.UpdateCells(testFrame("b"))
+	if err := g.flush(); err != nil {
+		t.Fatalf("Unexpected error flushing canvas: %s", err)
+	}
+
+	frames := c.frames()
+	if len(frames) != 2 || frameText(frames[1]) != "b" {
+		t.Errorf("Unexpected frames after flush [expected = 2 frames ending with b, actual = %d frames]", len(frames))
+	}
+
+	// nothing left to render
+	if err := g.flush(); err != nil {
+		t.Fatalf("Unexpected error flushing canvas: %s", err)
+	}
+	if len(c.frames()) != 2 {
+		t.Errorf("Unexpected frame rendered by flush with no new frame")
+	}
+}
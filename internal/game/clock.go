@@ -0,0 +1,93 @@
+package game
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultTickInterval is how often a real time clock ticks by default
+const DefaultTickInterval = time.Second / 60
+
+// Clock drives a game forward in time
+// the game only advances by the amount of time the clock reports, so a game can be played faster or slower than real time
+type Clock interface {
+	// Run sends how much time has passed since the previous tick, until the context is done
+	Run(ctx context.Context, ticks chan<- time.Duration)
+}
+
+// RealTime returns a clock which follows the system clock, ticking at the specified interval
+func RealTime(interval time.Duration) Clock {
+	return realTimeClock{interval: interval}
+}
+
+type realTimeClock struct {
+	interval time.Duration
+}
+
+func (r realTimeClock) Run(ctx context.Context, ticks chan<- time.Duration) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	last := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			// ticks are dropped by the ticker if the game is busy, so the next tick includes the time since the last one sent
+			select {
+			case ticks <- now.Sub(last):
+				last = now
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// ManualClock is a clock which only ticks when advanced, e.g. for tests, replays and bots
+// a manual clock can only drive a single game
+type ManualClock struct {
+	ticks   chan time.Duration
+	stopped chan struct{}
+	once    *sync.Once
+}
+
+// NewManualClock returns a new manual clock
+func NewManualClock() *ManualClock {
+	return &ManualClock{
+		ticks:   make(chan time.Duration),
+		stopped: make(chan struct{}),
+		once:    &sync.Once{},
+	}
+}
+
+// Advance ticks the clock, blocking until the game running with it receives the tick
+// false is returned if the game stops first
+func (m *ManualClock) Advance(d time.Duration) bool {
+	select {
+	case m.ticks <- d:
+		return true
+	case <-m.stopped:
+		return false
+	}
+}
+
+// Run forwards each tick to the game until the context is done
+func (m *ManualClock) Run(ctx context.Context, ticks chan<- time.Duration) {
+	defer m.once.Do(func() { close(m.stopped) })
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case d := <-m.ticks:
+			select {
+			case ticks <- d:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
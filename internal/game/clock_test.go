@@ -0,0 +1,66 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRealTimeClock(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		ticks       = make(chan time.Duration)
+		stopped     = make(chan struct{})
+		start       = time.Now()
+	)
+	go func() {
+		defer close(stopped)
+		RealTime(time.Millisecond).Run(ctx, ticks)
+	}()
+
+	var total time.Duration
+	for i := 0; i < 10; i++ {
+		d := <-ticks
+		if d <= 0 {
+			t.Errorf("Unexpected duration of tick %d: %s", i, d)
+		}
+		total += d
+	}
+	cancel()
+	<-stopped
+
+	// the ticks should add up to the time which actually passed
+	if elapsed := time.Since(start); total > elapsed {
+		t.Errorf("Unexpected total duration of ticks [elapsed = %s, actual = %s]", elapsed, total)
+	}
+}
+
+func TestManualClock(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		clock       = NewManualClock()
+		ticks       = make(chan time.Duration)
+		stopped     = make(chan struct{})
+	)
+	go func() {
+		defer close(stopped)
+		clock.Run(ctx, ticks)
+	}()
+
+	received := make(chan time.Duration)
+	go func() {
+		received <- <-ticks
+	}()
+	if !clock.Advance(time.Second) {
+		t.Errorf("Tick unexpectedly not received")
+	}
+	if d := <-received; d != time.Second {
+		t.Errorf("Unexpected tick [expected = %s, actual = %s]", time.Second, d)
+	}
+
+	cancel()
+	<-stopped
+	if clock.Advance(time.Second) {
+		t.Errorf("Tick unexpectedly received after the clock stopped")
+	}
+}
@@ -21,15 +21,25 @@ func (g *Game) DisplayPotentialColors() error {
 		return err
 	}
 
+	can.UpdateCells(g.PotentialColorCells())
+
+	return can.Render()
+}
+
+// PotentialColorCells generates the demo board with the potential pieces and colors
+// this allows the demo to be displayed alongside other cells (e.g. in a menu)
+func (g *Game) PotentialColorCells() [][]canvas.Cell {
 	boardWidth := boardWidth(g.board)
 	boardBlocks := [][]*board.Block{}
 
 	for i := range tetrimino.PieceConstructors {
 		var (
 			piece       = tetrimino.PieceConstructors[i](boardWidth, 4)
-			pieceBlocks = piece.Blocks()
+			pieceBlocks [][]*board.Block
 			ghostBlocks [][]*board.Block
 		)
+		g.colorPieces([]tetrimino.Tetrimino{piece})
+		pieceBlocks = piece.Blocks()
 		if !g.disableGhost {
 			ghost := piece.SpawnGhost()
 			ghostBlocks = ghost.Blocks()
@@ -47,8 +57,5 @@ func (g *Game) DisplayPotentialColors() error {
 	}
 	g.board.Blocks = boardBlocks
 	g.updateCells(g.board.Background())
-	cells := g.cells(g.board)
-	can.UpdateCells(cells)
-
-	return can.Render()
+	return g.cells(g.board)
 }
@@ -15,8 +15,8 @@ const (
 
 // ControlScheme represents a mapping of keys to user input
 type ControlScheme interface {
-	controlMap() map[string]userInput
-	keyMap() map[key]userInput
+	controlMap() map[string]Action
+	keyMap() map[key]Action
 	Description() string
 	String() string
 }
@@ -86,14 +86,14 @@ func spaceBar() key {
 
 type keyMapping struct {
 	name    string
-	mapping func() map[key]userInput
+	mapping func() map[key]Action
 }
 
-func (k keyMapping) keyMap() map[key]userInput {
+func (k keyMapping) keyMap() map[key]Action {
 	return k.mapping()
 }
 
-func (k keyMapping) controlMap() map[string]userInput {
+func (k keyMapping) controlMap() map[string]Action {
 	return ctrlMap(k)
 }
 
@@ -109,7 +109,7 @@ func (k keyMapping) String() string {
 func HomeRow() ControlScheme {
 	return keyMapping{
 		name: HomeRowName,
-		mapping: func() map[key]userInput {
+		mapping: func() map[key]Action {
 			var (
 				upKey          = key{name: "k", value: "k"}
 				downKey        = key{name: "j", value: "j"}
@@ -119,13 +119,13 @@ func HomeRow() ControlScheme {
 				rotateRightKey = key{name: "d", value: "d"}
 			)
 
-			return map[key]userInput{
-				upKey:          moveUp,
-				downKey:        moveDown,
-				rightKey:       moveRight,
-				leftKey:        moveLeft,
-				rotateLeftKey:  rotateLeft,
-				rotateRightKey: rotateRight,
+			return map[key]Action{
+				upKey:          MoveUp,
+				downKey:        MoveDown,
+				rightKey:       MoveRight,
+				leftKey:        MoveLeft,
+				rotateLeftKey:  RotateLeft,
+				rotateRightKey: RotateRight,
 			}
 		},
 	}
@@ -135,7 +135,7 @@ func HomeRow() ControlScheme {
 func ArrowKeys() ControlScheme {
 	return keyMapping{
 		name: ArrowKeysName,
-		mapping: func() map[key]userInput {
+		mapping: func() map[key]Action {
 			var (
 				upKey          = upArrow()
 				downKey        = downArrow()
@@ -145,13 +145,13 @@ func ArrowKeys() ControlScheme {
 				rotateRightKey = key{name: "x", value: "x"}
 			)
 
-			return map[key]userInput{
-				upKey:          moveUp,
-				downKey:        moveDown,
-				rightKey:       moveRight,
-				leftKey:        moveLeft,
-				rotateLeftKey:  rotateLeft,
-				rotateRightKey: rotateRight,
+			return map[key]Action{
+				upKey:          MoveUp,
+				downKey:        MoveDown,
+				rightKey:       MoveRight,
+				leftKey:        MoveLeft,
+				rotateLeftKey:  RotateLeft,
+				rotateRightKey: RotateRight,
 			}
 		},
 	}
@@ -161,7 +161,7 @@ func ArrowKeys() ControlScheme {
 func Standard() ControlScheme {
 	return keyMapping{
 		name: StandardName,
-		mapping: func() map[key]userInput {
+		mapping: func() map[key]Action {
 			var (
 				upKey    = upArrow()
 				downKey  = downArrow()
@@ -170,12 +170,12 @@ func Standard() ControlScheme {
 				spaceBar = spaceBar()
 			)
 
-			return map[key]userInput{
-				upKey:    rotateLeft,
-				downKey:  moveDown,
-				rightKey: moveRight,
-				leftKey:  moveLeft,
-				spaceBar: moveUp,
+			return map[key]Action{
+				upKey:    RotateLeft,
+				downKey:  MoveDown,
+				rightKey: MoveRight,
+				leftKey:  MoveLeft,
+				spaceBar: MoveUp,
 			}
 		},
 	}
@@ -184,8 +184,8 @@ func Standard() ControlScheme {
 // ControlSchemes is a union of one or more mappings of keys to user input
 type ControlSchemes []ControlScheme
 
-func (c ControlSchemes) keyMap() map[key]userInput {
-	keyMap := make(map[key]userInput)
+func (c ControlSchemes) keyMap() map[key]Action {
+	keyMap := make(map[key]Action)
 	for _, scheme := range c {
 		kMap := scheme.keyMap()
 		for k, v := range kMap {
@@ -196,7 +196,7 @@ func (c ControlSchemes) keyMap() map[key]userInput {
 	return keyMap
 }
 
-func (c ControlSchemes) controlMap() map[string]userInput {
+func (c ControlSchemes) controlMap() map[string]Action {
 	return ctrlMap(c)
 }
 
@@ -217,7 +217,7 @@ func schemeDescription(c ControlScheme) string {
 	var (
 		inputMap            = inputMap(c)
 		mappingDescriptions = []string{}
-		inputs              = []userInput{}
+		inputs              = []Action{}
 	)
 
 	for input := range inputMap {
@@ -244,10 +244,10 @@ func schemeDescription(c ControlScheme) string {
 	return strings.Join(mappingDescriptions, "\n")
 }
 
-func inputMap(c ControlScheme) map[userInput][]string {
+func inputMap(c ControlScheme) map[Action][]string {
 	var (
 		keyMap   = c.keyMap()
-		mappings = make(map[userInput][]string)
+		mappings = make(map[Action][]string)
 	)
 
 	for key, input := range keyMap {
@@ -265,10 +265,10 @@ func inputMap(c ControlScheme) map[userInput][]string {
 	return mappings
 }
 
-func ctrlMap(c ControlScheme) map[string]userInput {
+func ctrlMap(c ControlScheme) map[string]Action {
 	var (
 		keyMap     = c.keyMap()
-		controlMap = make(map[string]userInput)
+		controlMap = make(map[string]Action)
 	)
 	for key, input := range keyMap {
 		controlMap[key.String()] = input
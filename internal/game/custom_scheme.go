@@ -0,0 +1,179 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// the names of keys which can be used in custom schemes, in addition to single characters and ctrl+<letter>
+var namedKeys = map[string]func() key{
+	"up":        upArrow,
+	"down":      downArrow,
+	"right":     rightArrow,
+	"left":      leftArrow,
+	"space":     spaceBar,
+	"enter":     func() key { return key{name: "ENTER", value: "\n"} },
+	"tab":       func() key { return key{name: "TAB", value: "\t"} },
+	"backspace": func() key { return key{name: "BACKSPACE", value: "\u007f"} },
+	"esc":       func() key { return key{name: "ESC", value: "\u001b"} },
+	"home":      func() key { return key{name: "HOME", value: "\u001b[H"} },
+	"end":       func() key { return key{name: "END", value: "\u001b[F"} },
+	"insert":    func() key { return key{name: "INSERT", value: "\u001b[2~"} },
+	"delete":    func() key { return key{name: "DELETE", value: "\u001b[3~"} },
+	"pageup":    func() key { return key{name: "PAGEUP", value: "\u001b[5~"} },
+	"pagedown":  func() key { return key{name: "PAGEDOWN", value: "\u001b[6~"} },
+}
+
+const ctrlPrefix = "ctrl+"
+
+// reservedKeys are the control keys handled by the terminal itself, which never reach the game
+// signals are still enabled in game mode, and carriage returns are translated to newlines
+var reservedKeys = map[string]string{
+	"ctrl+c":  "interrupts the game",
+	"ctrl+z":  "suspends the game",
+	"ctrl+\\": "quits the game",
+	"ctrl+m":  "is received as ENTER",
+}
+
+// parseKey converts the name of a key to the value sent by the terminal
+// the name may be one of the named keys (e.g. "left", "space"), a control key (e.g. "ctrl+x"),
+// a single character, or a raw escape sequence (e.g. "\u001b[1;5C")
+func parseKey(name string) (key, error) {
+	lower := strings.ToLower(name)
+
+	if k, ok := namedKeys[lower]; ok {
+		return k(), nil
+	}
+
+	if reason, ok := reservedKeys[lower]; ok {
+		return key{}, fmt.Errorf("key '%s' can't be bound since it %s", name, reason)
+	}
+
+	if strings.HasPrefix(lower, ctrlPrefix) && len(lower) == len(ctrlPrefix)+1 {
+		letter := lower[len(ctrlPrefix)]
+		if letter < 'a' || letter > 'z' {
+			return key{}, fmt.Errorf("unrecognized control key '%s'", name)
+		}
+		return key{
+			name:  "CTRL+" + strings.ToUpper(string(letter)),
+			value: string(letter - 'a' + 1),
+		}, nil
+	}
+
+	if strings.HasPrefix(name, "\u001b") && len(name) > 1 {
+		// display escape sequences using caret notation
+		return key{
+			name:  strings.Replace(name, "\u001b", "^[", -1),
+			value: name,
+		}, nil
+	}
+
+	if utf8.RuneCountInString(name) == 1 {
+		r, _ := utf8.DecodeRuneInString(name)
+		if r == utf8.RuneError || r < ' ' || r == '\u007f' {
+			return key{}, fmt.Errorf("unsupported key %q, use the key name instead", name)
+		}
+		return key{name: name, value: name}, nil
+	}
+
+	return key{}, fmt.Errorf("unrecognized key '%s'", name)
+}
+
+// inputFromName converts the name of an action to the corresponding user input
+// both the description (e.g. "move left") and hyphenated (e.g. "move-left") forms are accepted
+func inputFromName(name string) (Action, error) {
+	normalized := strings.Replace(strings.ToLower(strings.TrimSpace(name)), "-", " ", -1)
+	for _, input := range []Action{MoveLeft, MoveRight, MoveDown, MoveUp, RotateLeft, RotateRight} {
+		if input.String() == normalized {
+			return input, nil
+		}
+	}
+	return ignore, fmt.Errorf("unrecognized action '%s'", name)
+}
+
+// CustomScheme creates a control scheme from a mapping of key names to action names
+// e.g. {"left": "move-left", "ctrl+r": "rotate-right", "space": "move-up"}
+func CustomScheme(name string, bindings map[string]string) (ControlScheme, error) {
+	if name == "" {
+		return nil, fmt.Errorf("custom control scheme must have a name")
+	}
+	if _, err := SchemeFromName(name); err == nil {
+		return nil, fmt.Errorf("custom control scheme '%s' conflicts with an existing scheme", name)
+	}
+	if len(bindings) == 0 {
+		return nil, fmt.Errorf("custom control scheme '%s' has no key bindings", name)
+	}
+
+	// sort to allow consistent errors
+	keyNames := []string{}
+	for keyName := range bindings {
+		keyNames = append(keyNames, keyName)
+	}
+	sort.Strings(keyNames)
+
+	var (
+		keyMap   = make(map[key]Action)
+		boundTo  = make(map[string]string)
+		keyError = func(keyName string, err error) error {
+			return fmt.Errorf("invalid binding for '%s' in control scheme '%s': %s", keyName, name, err)
+		}
+	)
+	for _, keyName := range keyNames {
+		k, err := parseKey(keyName)
+		if err != nil {
+			return nil, keyError(keyName, err)
+		}
+		input, err := inputFromName(bindings[keyName])
+		if err != nil {
+			return nil, keyError(keyName, err)
+		}
+		if other, ok := boundTo[k.value]; ok {
+			return nil, keyError(keyName, fmt.Errorf("same key as '%s'", other))
+		}
+		boundTo[k.value] = keyName
+		keyMap[k] = input
+	}
+
+	return keyMapping{
+		name: name,
+		mapping: func() map[key]Action {
+			return keyMap
+		},
+	}, nil
+}
+
+// Validate checks that no key is bound to different actions by the combined schemes
+func (c ControlSchemes) Validate() error {
+	type binding struct {
+		scheme string
+		input  Action
+	}
+
+	bindings := make(map[string]binding)
+	for _, scheme := range c {
+		kMap := scheme.keyMap()
+
+		// sort to allow consistent errors
+		keys := []key{}
+		for k := range kMap {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i].value < keys[j].value
+		})
+
+		for _, k := range keys {
+			input := kMap[k]
+			if existing, ok := bindings[k.value]; ok && existing.input != input {
+				return fmt.Errorf(
+					"key '%s' is bound to '%s' by control scheme '%s' and '%s' by control scheme '%s'",
+					k.displayKey(), existing.input, existing.scheme, input, scheme,
+				)
+			}
+			bindings[k.value] = binding{scheme: scheme.String(), input: input}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package game
+
+import "testing"
+
+var parseKeyTests = map[string]struct {
+	name          string
+	expectedKey   key
+	expectedError bool
+}{
+	"single character": {
+		name:        "k",
+		expectedKey: key{name: "k", value: "k"},
+	},
+	"upper case character": {
+		name:        "K",
+		expectedKey: key{name: "K", value: "K"},
+	},
+	"named arrow key": {
+		name:        "left",
+		expectedKey: leftArrow(),
+	},
+	"named key with different case": {
+		name:        "Space",
+		expectedKey: spaceBar(),
+	},
+	"control key": {
+		name:        "ctrl+x",
+		expectedKey: key{name: "CTRL+X", value: "\u0018"},
+	},
+	"control key with different case": {
+		name:        "Ctrl+A",
+		expectedKey: key{name: "CTRL+A", value: "\u0001"},
+	},
+	"escape sequence": {
+		name:        "\u001b[1;5C",
+		expectedKey: key{name: "^[[1;5C", value: "\u001b[1;5C"},
+	},
+	"control key with non-letter": {
+		name:          "ctrl+1",
+		expectedError: true,
+	},
+	"interrupt key": {
+		name:          "ctrl+c",
+		expectedError: true,
+	},
+	"suspend key": {
+		name:          "Ctrl+Z",
+		expectedError: true,
+	},
+	"quit key": {
+		name:          "ctrl+\\",
+		expectedError: true,
+	},
+	"carriage return": {
+		name:          "ctrl+m",
+		expectedError: true,
+	},
+	"raw control character": {
+		name:          "\u0018",
+		expectedError: true,
+	},
+	"unrecognized name": {
+		name:          "shift",
+		expectedError: true,
+	},
+	"empty name": {
+		name:          "",
+		expectedError: true,
+	},
+}
+
+func TestParseKey(t *testing.T) {
+	for testName, test := range parseKeyTests {
+		k, err := parseKey(test.name)
+		if err != nil {
+			if !test.expectedError {
+				t.Errorf("Unexpected error for test case '%s': %s", testName, err)
+			}
+			continue
+		}
+		if test.expectedError {
+			t.Errorf("Unexpectedly no error for test case '%s'", testName)
+		}
+		if k != test.expectedKey {
+			t.Errorf("Unexpected key for test case '%s' [expected = %#v, actual = %#v]", testName, test.expectedKey, k)
+		}
+	}
+}
+
+var customSchemeTests = map[string]struct {
+	name                string
+	bindings            map[string]string
+	expectedDescription string
+	expectedError       bool
+}{
+	"vim style": {
+		name: "vim",
+		bindings: map[string]string{
+			"h":      "move-left",
+			"l":      "move-right",
+			"j":      "move-down",
+			"space":  "move-up",
+			"ctrl+r": "rotate-right",
+			"u":      "rotate left",
+		},
+		expectedDescription: "move left: h\nmove right: l\nmove down: j\nmove up: SPACE\nrotate left: u\nrotate right: CTRL+R",
+	},
+	"multiple keys for action": {
+		name: "arrows",
+		bindings: map[string]string{
+			"left":  "move-left",
+			"a":     "move-left",
+			"right": "move-right",
+		},
+		expectedDescription: "move left: a, ←\nmove right: →",
+	},
+	"no name": {
+		bindings:      map[string]string{"h": "move-left"},
+		expectedError: true,
+	},
+	"name of existing scheme": {
+		name:          HomeRowName,
+		bindings:      map[string]string{"h": "move-left"},
+		expectedError: true,
+	},
+	"no bindings": {
+		name:          "empty",
+		expectedError: true,
+	},
+	"unrecognized key": {
+		name:          "bad-key",
+		bindings:      map[string]string{"hyper": "move-left"},
+		expectedError: true,
+	},
+	"unrecognized action": {
+		name:          "bad-action",
+		bindings:      map[string]string{"h": "hold"},
+		expectedError: true,
+	},
+	"same key bound twice": {
+		name: "conflict",
+		bindings: map[string]string{
+			"space": "move-up",
+			" ":     "move-down",
+		},
+		expectedError: true,
+	},
+}
+
+func TestCustomScheme(t *testing.T) {
+	for testName, test := range customSchemeTests {
+		scheme, err := CustomScheme(test.name, test.bindings)
+		if err != nil {
+			if !test.expectedError {
+				t.Errorf("Unexpected error for test case '%s': %s", testName, err)
+			}
+			continue
+		}
+		if test.expectedError {
+			t.Errorf("Unexpectedly no error for test case '%s'", testName)
+		}
+
+		if scheme.Description() != test.expectedDescription {
+			t.Errorf("Unexpected description for test case '%s' [expected = %s, actual = %s]", testName, test.expectedDescription, scheme.Description())
+		}
+		if scheme.String() != test.name {
+			t.Errorf("Unexpected name for test case '%s' [expected = %s, actual = %s]", testName, test.name, scheme.String())
+		}
+	}
+}
+
+func mustCustomScheme(name string, bindings map[string]string) ControlScheme {
+	scheme, err := CustomScheme(name, bindings)
+	if err != nil {
+		panic(err)
+	}
+	return scheme
+}
+
+var validateSchemesTests = map[string]struct {
+	schemes       ControlSchemes
+	expectedError bool
+}{
+	"home row and arrow keys": {
+		schemes: ControlSchemes{HomeRow(), ArrowKeys()},
+	},
+	"same key for same action": {
+		schemes: ControlSchemes{HomeRow(), mustCustomScheme("extra", map[string]string{"h": "move-left", "q": "rotate-left"})},
+	},
+	"arrow keys and standard": {
+		schemes:       ControlSchemes{ArrowKeys(), Standard()},
+		expectedError: true,
+	},
+	"custom scheme conflicts with home row": {
+		schemes:       ControlSchemes{HomeRow(), mustCustomScheme("conflict", map[string]string{"j": "move-up"})},
+		expectedError: true,
+	},
+}
+
+func TestValidateSchemes(t *testing.T) {
+	for testName, test := range validateSchemesTests {
+		err := test.schemes.Validate()
+		if err != nil && !test.expectedError {
+			t.Errorf("Unexpected error for test case '%s': %s", testName, err)
+		}
+		if err == nil && test.expectedError {
+			t.Errorf("Unexpectedly no error for test case '%s'", testName)
+		}
+	}
+}
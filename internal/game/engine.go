@@ -0,0 +1,613 @@
+package game
+
+import (
+	"time"
+
+	"github.com/ShawnROGrady/gotris/internal/canvas"
+	"github.com/ShawnROGrady/gotris/internal/game/board"
+	"github.com/ShawnROGrady/gotris/internal/game/tetrimino"
+)
+
+// Engine implements the rules of the game, without displaying it or reading any input
+// actions and ticks are applied by whatever is driving the engine (e.g. a Game, a bot or a test),
+// which can then inspect the resulting state
+// an engine isn't safe for concurrent use
+type Engine struct {
+	board          *board.Board
+	currentPiece   tetrimino.Tetrimino
+	ghostPiece     tetrimino.Tetrimino
+	newPieceSet    func(width, height int) []tetrimino.Tetrimino
+	seed           int64 // the seed of the random source used by newPieceSet
+	nextPieces     []tetrimino.Tetrimino
+	level          level
+	currentScore   int
+	linesCleared   int
+	lines          int
+	mode           Mode
+	endReason      EndReason
+	debugMode      bool
+	partialLockOut bool
+	pieceColors    map[tetrimino.Kind]canvas.Color
+	stats          Stats
+	elapsed        time.Duration // the game time, which only passes when the engine is advanced
+	untilTick      time.Duration // the game time until gravity is next applied
+	ticks          int           // the number of times gravity has been applied
+	subscribers    []func(Event)
+}
+
+func newEngine() *Engine {
+	seed := newSeed()
+	return &Engine{
+		newPieceSet: tetrimino.SeededSets(seed),
+		seed:        seed,
+		mode:        Marathon(),
+		stats:       newStats(),
+	}
+}
+
+// maxSeed is the limit of random seeds, which are kept small enough to be represented exactly as a float64
+// so the seed isn't rounded by anything decoding the result as JSON (e.g. JavaScript)
+const maxSeed = 1 << 53
+
+// newSeed returns a seed based on the current time
+func newSeed() int64 {
+	return time.Now().UnixNano() % maxSeed
+}
+
+// NewEngine returns a new engine with the specified specifications
+// options which only change how the game is displayed or controlled have no effect
+func NewEngine(opts ...Option) *Engine {
+	// the options are applied to a game, but only its engine is kept
+	g := &Game{Engine: newEngine()}
+	boardOpts, _ := g.applyOptions(opts)
+	g.init(boardOpts)
+	return g.Engine
+}
+
+// init creates the board and the first pieces
+func (e *Engine) init(boardOpts []board.Option) {
+	e.board = board.New(boardOpts...)
+
+	initPieces := e.newPieceSet(boardWidth(e.board), boardHeight(e.board))
+	e.colorPieces(initPieces)
+	e.currentPiece, e.nextPieces = initPieces[0], initPieces[1:]
+}
+
+// Start adds the first piece to the board
+// it must be called before any actions or ticks are applied
+func (e *Engine) Start() {
+	e.addPieceToBoard(e.currentPiece)
+	e.ghostPiece = e.findGhostPiece()
+	e.untilTick = e.GravityInterval()
+	e.emit(Event{Type: PieceSpawned, Piece: e.currentPiece.Kind()})
+}
+
+// Apply performs the action on the current piece, returning true if the piece was locked in place as a result
+// nothing happens once the game has ended
+func (e *Engine) Apply(action Action) bool {
+	if e.endReason != NotOver {
+		return false
+	}
+
+	var (
+		topL     = e.currentPiece.ContainingBox().TopLeft
+		blocks   = e.currentPiece.Blocks()
+		canSlide = true
+	)
+
+	e.movePiece(action)
+
+	if action == RotateLeft || action == RotateRight {
+		if e.pieceOutOfBounds() || e.pieceConflicts(topL, blocks) {
+			if !e.resolveRotation() {
+				// move back to original spot
+				if opposite := action.opposite(); opposite != ignore {
+					e.movePiece(opposite)
+					e.ghostPiece = e.findGhostPiece()
+					return false
+				}
+			}
+		}
+	}
+
+	// new space already occupied
+	if (action == MoveLeft || action == MoveRight || (action == MoveUp && e.debugMode)) && (e.pieceOutOfBounds() || e.pieceConflicts(topL, blocks)) {
+		// move back to original spot
+		if opposite := action.opposite(); opposite != ignore {
+			e.movePiece(opposite)
+			e.ghostPiece = e.findGhostPiece()
+			return false
+		}
+	}
+
+	// piece was already at the bottom
+	if (!e.debugMode && action == MoveUp) || e.pieceOutOfBounds() || e.pieceConflicts(topL, blocks) {
+		canSlide = false
+		if e.pieceOutOfBounds() || e.pieceConflicts(topL, blocks) {
+			if opposite := action.opposite(); opposite != ignore {
+				e.movePiece(opposite)
+				e.ghostPiece = e.findGhostPiece()
+			}
+		}
+	}
+	e.ghostPiece = e.findGhostPiece()
+
+	// clear cell where piece was
+	for i, row := range blocks {
+		for j, block := range row {
+			if block == nil {
+				continue
+			}
+			x := topL.X + j
+			y := topL.Y - i
+
+			e.board.Blocks[y][x] = nil
+		}
+	}
+
+	// update cell at pieces new position
+	e.addPieceToBoard(e.currentPiece)
+	e.ghostPiece = e.findGhostPiece()
+
+	// generate new current piece if at bottom or on top of another piece
+	if e.pieceAtBottom(e.currentPiece) && !canSlide {
+		e.lockPiece()
+		return true
+	}
+	return false
+}
+
+// Tick applies gravity to the current piece, returning true if the piece was locked in place as a result
+// how often the engine should be ticked is given by GravityInterval, see Advance
+func (e *Engine) Tick() bool {
+	e.ticks++
+	return e.Apply(MoveDown)
+}
+
+// Advance moves the game forward in time, returning true if a piece was locked in place as a result
+// gravity is applied once every GravityInterval, and the game ends once the time limit of the mode is reached
+// no time passes unless the engine is advanced, so the game is deterministic regardless of how quickly it's advanced
+func (e *Engine) Advance(d time.Duration) bool {
+	if e.debugMode {
+		// gravity and time limits are disabled in debug mode
+		e.elapsed += d
+		return false
+	}
+
+	locked := false
+	for d > 0 && e.endReason == NotOver {
+		// stop at the next event (gravity or the time limit) so it happens at the right time
+		step := d
+		if e.untilTick < step {
+			step = e.untilTick
+		}
+		limit := e.mode.timeLimit
+		if limit != 0 && limit-e.elapsed < step {
+			step = limit - e.elapsed
+		}
+		d -= step
+		e.elapsed += step
+
+		if limit != 0 && e.elapsed >= limit {
+			e.End(TimeUp)
+			break
+		}
+		if e.untilTick -= step; e.untilTick <= 0 {
+			if e.Tick() {
+				locked = true
+			}
+			e.untilTick = e.GravityInterval()
+		}
+	}
+	return locked
+}
+
+// lockPiece locks the current piece in place, clearing any full rows then spawning the next piece
+func (e *Engine) lockPiece() {
+	e.emit(Event{Type: PieceLocked, Piece: e.currentPiece.Kind()})
+	if reason := e.lockOutReason(); reason != NotOver {
+		e.End(reason)
+		return
+	}
+
+	// check if any rows can be cleared
+	linesCleared := e.board.ClearFullRows()
+	if linesCleared != 0 {
+		e.lines += linesCleared
+		e.linesCleared += linesCleared
+		e.currentScore += e.level.linePoints(linesCleared)
+		e.emit(Event{Type: LinesCleared, Lines: linesCleared})
+
+		if newLevel := e.level.updatedLevel(e.linesCleared); newLevel != e.level {
+			e.level = newLevel
+			e.emit(Event{Type: LevelUp, Level: int(newLevel)})
+		}
+	}
+
+	if e.mode.goalReached(e.lines) {
+		e.End(GoalReached)
+		return
+	}
+
+	e.currentPiece = e.nextPiece()
+	if e.pieceOverlaps(e.currentPiece) {
+		// new piece spawned on top of existing blocks
+		e.End(BlockOut)
+		return
+	}
+	e.ghostPiece = e.findGhostPiece()
+
+	// add new piece to the board
+	e.addPieceToBoard(e.currentPiece)
+	e.emit(Event{Type: PieceSpawned, Piece: e.currentPiece.Kind()})
+}
+
+// End ends the game for the specified reason, unless it has already ended
+// the engine ends the game itself when topping out, reaching the line goal or reaching the time limit
+func (e *Engine) End(reason EndReason) {
+	if e.endReason != NotOver {
+		return
+	}
+	e.endReason = reason
+	e.emit(Event{Type: GameOver, Reason: reason})
+}
+
+// EndReason returns the reason the game ended
+// NotOver is returned if the game is still in progress
+func (e *Engine) EndReason() EndReason {
+	return e.endReason
+}
+
+// Board returns the board, including the current piece but not the ghost piece
+// it shouldn't be modified
+func (e *Engine) Board() *board.Board {
+	return e.board
+}
+
+// CurrentPiece returns the piece being moved
+func (e *Engine) CurrentPiece() tetrimino.Tetrimino {
+	return e.currentPiece
+}
+
+// GhostPiece returns where the current piece would land if hard dropped
+func (e *Engine) GhostPiece() tetrimino.Tetrimino {
+	return e.ghostPiece
+}
+
+// Seed returns the seed used to generate the order of the pieces, which can be passed to WithSeed to play the same pieces again
+func (e *Engine) Seed() int64 {
+	return e.seed
+}
+
+// NextPieces returns the pieces which will be spawned next, in order
+func (e *Engine) NextPieces() []tetrimino.Tetrimino {
+	return append([]tetrimino.Tetrimino{}, e.nextPieces...)
+}
+
+// Score returns the current score
+func (e *Engine) Score() int {
+	return e.currentScore
+}
+
+// Level returns the current level
+func (e *Engine) Level() int {
+	return int(e.level)
+}
+
+// Lines returns the number of lines cleared
+func (e *Engine) Lines() int {
+	return e.lines
+}
+
+// GravityInterval returns how long to wait between ticks at the current level
+func (e *Engine) GravityInterval() time.Duration {
+	return e.level.gTime()
+}
+
+// TimeLimit returns the time limit of the mode, 0 if there isn't one
+func (e *Engine) TimeLimit() time.Duration {
+	return e.mode.timeLimit
+}
+
+func (e *Engine) addPieceToBoard(piece tetrimino.Tetrimino) {
+	drawPiece(e.board.Blocks, piece)
+}
+
+// drawPiece places the blocks of the piece on the board, replacing any blocks already there
+func drawPiece(boardBlocks [][]*board.Block, piece tetrimino.Tetrimino) {
+	var (
+		topL   = piece.ContainingBox().TopLeft
+		blocks = piece.Blocks()
+	)
+
+	for i, row := range blocks {
+		for j, block := range row {
+			if block == nil {
+				continue
+			}
+			x := topL.X + j
+			y := topL.Y - i
+
+			boardBlocks[y][x] = block
+		}
+	}
+}
+
+// pieceConflicts checks if the current piece is in an occupied space
+func (e *Engine) pieceConflicts(oldTopL tetrimino.Coordinates, oldBlocks [][]*board.Block) bool {
+	var (
+		topL       = e.currentPiece.ContainingBox().TopLeft
+		blocks     = e.currentPiece.Blocks()
+		prevCoords = make(map[tetrimino.Coordinates]bool)
+	)
+
+	for i, row := range oldBlocks {
+		for j, block := range row {
+			if block == nil {
+				continue
+			}
+			x := oldTopL.X + j
+			y := oldTopL.Y - i
+			prevCoords[tetrimino.Coordinates{
+				X: x,
+				Y: y,
+			}] = true
+		}
+	}
+
+	for i, row := range blocks {
+		for j, block := range row {
+			if block == nil {
+				continue
+			}
+			x := topL.X + j
+			y := topL.Y - i
+
+			if e.board.Blocks[y][x] != nil && !prevCoords[tetrimino.Coordinates{X: x, Y: y}] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// pieceOutOfBounds checks if the piece is no longer in the bounds of the board
+// this can happen after a rotation
+func (e *Engine) pieceOutOfBounds() bool {
+	var (
+		topL   = e.currentPiece.ContainingBox().TopLeft
+		blocks = e.currentPiece.Blocks()
+	)
+	for i, row := range blocks {
+		for j, block := range row {
+			if block == nil {
+				continue
+			}
+			x := topL.X + j
+			y := topL.Y - i
+
+			// can't rotate due to horizontal constraints
+			if x < 0 || x > boardWidth(e.board)-1 {
+				return true
+			}
+
+			if y < 0 || y > boardHeight(e.board)-1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checks if any part of the current piece is in the hidden row(s)
+func (e *Engine) pieceAtTop() bool {
+	return e.currentPiece.YMax().Y > len(e.board.Blocks)-e.board.HiddenRows()-1
+}
+
+// current piece is at minimum vertical position
+// either at bottom or on top of another piece
+func (e *Engine) pieceAtBottom(piece tetrimino.Tetrimino) bool {
+	var (
+		topL        = piece.ContainingBox().TopLeft
+		blocks      = piece.Blocks()
+		pieceCoords = make(map[tetrimino.Coordinates]bool)
+	)
+
+	if piece.YMin().Y <= 0 {
+		return true
+	}
+
+	for i, row := range blocks {
+		for j, block := range row {
+			if block == nil {
+				continue
+			}
+			x := topL.X + j
+			y := topL.Y - i
+			pieceCoords[tetrimino.Coordinates{
+				X: x,
+				Y: y,
+			}] = true
+		}
+	}
+
+	for i, row := range blocks {
+		for j, block := range row {
+			if block == nil {
+				continue
+			}
+			x := topL.X + j
+			y := topL.Y - i
+
+			if e.board.Blocks[y-1][x] != nil && !pieceCoords[tetrimino.Coordinates{X: x, Y: y - 1}] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (e *Engine) movePiece(input Action) {
+	var (
+		piece = e.currentPiece
+	)
+
+	switch input {
+	case MoveLeft:
+		piece.MoveLeft()
+	case MoveDown:
+		piece.MoveDown()
+	case MoveUp:
+		if e.debugMode {
+			piece.MoveUp()
+		} else {
+			// hard drop
+			e.ghostPiece.ToggleGhost()
+			e.currentPiece = e.ghostPiece
+			e.ghostPiece = nil
+		}
+	case MoveRight:
+		piece.MoveRight()
+	case RotateLeft:
+		piece.RotateCounter()
+	case RotateRight:
+		piece.RotateClockwise()
+	}
+}
+
+func (e *Engine) nextPiece() tetrimino.Tetrimino {
+	var nextPiece tetrimino.Tetrimino
+	if len(e.nextPieces) == 1 {
+		var (
+			boardWidth  = boardWidth(e.board)
+			boardHeight = boardHeight(e.board)
+		)
+		nextPiece = e.nextPieces[0]
+		e.nextPieces = e.newPieceSet(boardWidth, boardHeight)
+		e.colorPieces(e.nextPieces)
+		return nextPiece
+	}
+	nextPiece, e.nextPieces = e.nextPieces[0], e.nextPieces[1:]
+	return nextPiece
+}
+
+// colorPieces applies the piece colors of the theme, if any
+func (e *Engine) colorPieces(pieces []tetrimino.Tetrimino) {
+	for _, piece := range pieces {
+		if color, ok := e.pieceColors[piece.Kind()]; ok {
+			piece.SetColor(color)
+		}
+	}
+}
+
+func (e *Engine) resolveRotation() bool {
+	var (
+		piece  = e.currentPiece
+		topL   = e.currentPiece.ContainingBox().TopLeft
+		blocks = e.currentPiece.Blocks()
+	)
+
+	for _, rotationTest := range piece.RotationTests() {
+		rotationTest.ApplyTest()
+		if !(e.pieceOutOfBounds() || e.pieceConflicts(topL, blocks)) {
+			return true
+		}
+
+		rotationTest.RevertTest()
+	}
+
+	return false
+}
+
+func (e *Engine) findGhostPiece() tetrimino.Tetrimino {
+	var (
+		ghost = e.currentPiece.SpawnGhost()
+	)
+
+	for !e.pieceAtBottom(ghost) {
+		ghost.MoveDown()
+	}
+
+	return ghost
+}
+
+// the board with the ghost piece included
+// should NOT modify actual game board since ghost piece is irrelevant to game logic
+func (e *Engine) boardWithGhost() *board.Board {
+	var (
+		ghost       = e.ghostPiece
+		ghostTopL   = ghost.ContainingBox().TopLeft
+		ghostBlocks = ghost.Blocks()
+		newBoard    = e.copyBoard()
+	)
+
+	currentPieceCoords := pieceCoords(e.currentPiece, e.board.Blocks)
+
+	for i := range ghostBlocks {
+		for j, block := range ghostBlocks[i] {
+			if block == nil {
+				continue
+			}
+			x := ghostTopL.X + j
+			y := ghostTopL.Y - i
+
+			if currentPieceCoords[tetrimino.Coordinates{X: x, Y: y}] {
+				// active piece should be displayed in case of conflict with ghost
+				continue
+			}
+			newBoard.Blocks[y][x] = block
+		}
+	}
+	return newBoard
+}
+
+// the board with the piece which caused a block out drawn over the blocks it overlaps, since it was never added to the board
+// should NOT modify actual game board, so the final state of the game is unchanged
+func (e *Engine) boardWithBlockOut() *board.Board {
+	newBoard := e.copyBoard()
+	drawPiece(newBoard.Blocks, e.currentPiece)
+	return newBoard
+}
+
+// copyBoard returns a copy of the board whose blocks can be changed without affecting the game
+func (e *Engine) copyBoard() *board.Board {
+	var (
+		newBoard  = *e.board
+		newBlocks [][]*board.Block
+	)
+
+	for i := range e.board.Blocks {
+		row := []*board.Block{}
+		for j := range e.board.Blocks[i] {
+			row = append(row, e.board.Blocks[i][j])
+		}
+		newBlocks = append(newBlocks, row)
+	}
+	newBoard.Blocks = newBlocks
+	return &newBoard
+}
+
+func pieceCoords(piece tetrimino.Tetrimino, boardBlocks [][]*board.Block) map[tetrimino.Coordinates]bool {
+	var (
+		topL        = piece.ContainingBox().TopLeft
+		blocks      = piece.Blocks()
+		pieceCoords = make(map[tetrimino.Coordinates]bool)
+	)
+
+	for i, row := range blocks {
+		for j, block := range row {
+			if block == nil {
+				continue
+			}
+			x := topL.X + j
+			y := topL.Y - i
+			pieceCoords[tetrimino.Coordinates{
+				X: x,
+				Y: y,
+			}] = true
+		}
+	}
+	return pieceCoords
+}
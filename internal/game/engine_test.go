@@ -0,0 +1,216 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ShawnROGrady/gotris/internal/game/tetrimino"
+)
+
+var engineTests = map[string]struct {
+	options        []Option
+	actions        []Action
+	ticks          int
+	expectedLocks  int
+	expectGameOver bool
+}{
+	"move and rotate": {
+		actions: []Action{MoveLeft, MoveRight, RotateLeft, RotateRight},
+	},
+	"hard drop": {
+		actions:       []Action{MoveUp},
+		expectedLocks: 1,
+	},
+	"soft drop to bottom": {
+		// every piece reaches the bottom within 22 moves, but the next piece doesn't
+		actions:       fillInputSequence(MoveDown, 29),
+		expectedLocks: 1,
+	},
+	"ticks to bottom": {
+		ticks:         30,
+		expectedLocks: 1,
+	},
+	"hard drop until top out": {
+		actions:        fillInputSequence(MoveUp, 200),
+		expectGameOver: true,
+	},
+	"line goal": {
+		// with a width of 4 every horizontal 'I' piece clears a line, but not every piece is an 'I' piece
+		options:        []Option{WithBoardSize(4, 20), WithMode(Mode{lineGoal: 1})},
+		actions:        fillInputSequence(MoveUp, 200),
+		expectGameOver: true,
+	},
+}
+
+func TestEngine(t *testing.T) {
+	for testName, test := range engineTests {
+		e := NewEngine(test.options...)
+		e.Start()
+
+		locks := 0
+		for _, action := range test.actions {
+			if e.EndReason() != NotOver {
+				if e.Apply(action) {
+					t.Errorf("Unexpected lock after the game ended for test case '%s'", testName)
+				}
+				continue
+			}
+			if e.Apply(action) {
+				locks++
+			}
+		}
+		for i := 0; i < test.ticks; i++ {
+			if e.Tick() {
+				locks++
+			}
+		}
+
+		if gameOver := e.EndReason() != NotOver; gameOver != test.expectGameOver {
+			t.Errorf("Unexpected game over state for test case '%s' [expected = %v, actual = %v, reason = %s]", testName, test.expectGameOver, gameOver, e.EndReason())
+		}
+		if test.expectGameOver {
+			continue
+		}
+
+		if locks != test.expectedLocks {
+			t.Errorf("Unexpected number of locked pieces for test case '%s' [expected = %d, actual = %d]", testName, test.expectedLocks, locks)
+		}
+		if pieces := e.Stats().Pieces; pieces != locks {
+			t.Errorf("Unexpected pieces in stats for test case '%s' [expected = %d, actual = %d]", testName, locks, pieces)
+		}
+		if len(e.NextPieces()) == 0 {
+			t.Errorf("Unexpectedly no next pieces for test case '%s'", testName)
+		}
+	}
+}
+
+func TestEngineOptions(t *testing.T) {
+	e := NewEngine(WithInitialLevel(5), WithMode(Mode{timeLimit: time.Minute}), WithBoardSize(8, 16))
+
+	if e.Level() != 5 {
+		t.Errorf("Unexpected level [expected = %d, actual = %d]", 5, e.Level())
+	}
+	if interval := e.GravityInterval(); interval != level(5).gTime() {
+		t.Errorf("Unexpected gravity interval [expected = %s, actual = %s]", level(5).gTime(), interval)
+	}
+	if e.TimeLimit() != time.Minute {
+		t.Errorf("Unexpected time limit [expected = %s, actual = %s]", time.Minute, e.TimeLimit())
+	}
+	if width := boardWidth(e.Board()); width != 8 {
+		t.Errorf("Unexpected board width [expected = %d, actual = %d]", 8, width)
+	}
+}
+
+// kinds returns the kinds of the current and next pieces, hard dropping enough pieces to need several new sets
+func kinds(e *Engine) []tetrimino.Kind {
+	e.Start()
+	kinds := []tetrimino.Kind{}
+	for i := 0; i < 20 && e.EndReason() == NotOver; i++ {
+		kinds = append(kinds, e.CurrentPiece().Kind())
+		e.Apply(MoveUp)
+	}
+	for _, piece := range e.NextPieces() {
+		kinds = append(kinds, piece.Kind())
+	}
+	return kinds
+}
+
+func TestEngineSeed(t *testing.T) {
+	var (
+		expected = kinds(NewEngine(WithSeed(42)))
+		actual   = kinds(NewEngine(WithSeed(42)))
+	)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Unexpected pieces for the same seed [expected = %v, actual = %v]", expected, actual)
+	}
+	if other := kinds(NewEngine(WithSeed(43))); reflect.DeepEqual(other, expected) {
+		t.Errorf("Unexpectedly the same pieces for a different seed [pieces = %v]", other)
+	}
+
+	e := NewEngine(WithSeed(42))
+	if e.Seed() != 42 || e.Result().Seed != 42 {
+		t.Errorf("Unexpected seed [expected = %d, actual = %d, result = %d]", 42, e.Seed(), e.Result().Seed)
+	}
+
+	// without a seed one is chosen at random, which is small enough to be encoded in JSON without rounding
+	if seed := NewEngine().Seed(); seed < 0 || seed >= maxSeed {
+		t.Errorf("Unexpected random seed [seed = %d]", seed)
+	}
+}
+
+func TestEngineEnd(t *testing.T) {
+	e := NewEngine()
+	e.Start()
+
+	e.End(TimeUp)
+	e.End(BlockOut)
+	if e.EndReason() != TimeUp {
+		t.Errorf("Unexpected end reason [expected = %s, actual = %s]", TimeUp, e.EndReason())
+	}
+	if result := e.Result(); result.Reason != TimeUp {
+		t.Errorf("Unexpected result end reason [expected = %s, actual = %s]", TimeUp, result.Reason)
+	}
+}
+
+var engineAdvanceTests = map[string]struct {
+	options         []Option
+	advances        []time.Duration
+	expectedTicks   int
+	expectedElapsed time.Duration
+	expectedReason  EndReason
+}{
+	"less than the gravity interval": {
+		advances:        []time.Duration{999 * time.Millisecond},
+		expectedElapsed: 999 * time.Millisecond,
+	},
+	"gravity interval": {
+		advances:        []time.Duration{time.Second},
+		expectedTicks:   1,
+		expectedElapsed: time.Second,
+	},
+	"gravity interval split across advances": {
+		advances:        []time.Duration{600 * time.Millisecond, 600 * time.Millisecond},
+		expectedTicks:   1,
+		expectedElapsed: 1200 * time.Millisecond,
+	},
+	"several gravity intervals at once": {
+		advances:        []time.Duration{3500 * time.Millisecond},
+		expectedTicks:   3,
+		expectedElapsed: 3500 * time.Millisecond,
+	},
+	"time limit": {
+		// the time limit is reached before gravity is applied a second time
+		options:         []Option{WithMode(Mode{timeLimit: 2 * time.Second})},
+		advances:        []time.Duration{5 * time.Second, time.Second},
+		expectedTicks:   1,
+		expectedElapsed: 2 * time.Second,
+		expectedReason:  TimeUp,
+	},
+	"debug mode": {
+		options:         []Option{WithDebugMode(), WithMode(Mode{timeLimit: 2 * time.Second})},
+		advances:        []time.Duration{5 * time.Second},
+		expectedElapsed: 5 * time.Second,
+	},
+}
+
+func TestEngineAdvance(t *testing.T) {
+	for testName, test := range engineAdvanceTests {
+		e := NewEngine(test.options...)
+		e.Start()
+
+		for _, d := range test.advances {
+			e.Advance(d)
+		}
+
+		if e.ticks != test.expectedTicks {
+			t.Errorf("Unexpected number of ticks for test case '%s' [expected = %d, actual = %d]", testName, test.expectedTicks, e.ticks)
+		}
+		if elapsed := e.Stats().Elapsed; elapsed != test.expectedElapsed {
+			t.Errorf("Unexpected elapsed time for test case '%s' [expected = %s, actual = %s]", testName, test.expectedElapsed, elapsed)
+		}
+		if e.EndReason() != test.expectedReason {
+			t.Errorf("Unexpected end reason for test case '%s' [expected = %s, actual = %s]", testName, test.expectedReason, e.EndReason())
+		}
+	}
+}
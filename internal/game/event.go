@@ -0,0 +1,74 @@
+package game
+
+import (
+	"time"
+
+	"github.com/ShawnROGrady/gotris/internal/game/tetrimino"
+)
+
+// EventType identifies what happened in an event
+type EventType int
+
+// the types of event
+const (
+	// PieceSpawned means a new current piece was spawned, Piece is its kind
+	PieceSpawned EventType = iota
+	// PieceLocked means the current piece was locked in place, Piece is its kind
+	PieceLocked
+	// LinesCleared means full rows were cleared, Lines is the number of rows
+	LinesCleared
+	// LevelUp means the level increased, Level is the new level
+	LevelUp
+	// GameOver means the game ended, Reason is why
+	GameOver
+)
+
+func (t EventType) String() string {
+	typeNames := map[EventType]string{
+		PieceSpawned: "piece spawned",
+		PieceLocked:  "piece locked",
+		LinesCleared: "lines cleared",
+		LevelUp:      "level up",
+		GameOver:     "game over",
+	}
+
+	return typeNames[t]
+}
+
+// Event is something which happened during a game
+// only the fields relevant to the type of event are set
+type Event struct {
+	Type   EventType
+	Time   time.Duration // the game time at which the event happened
+	Piece  tetrimino.Kind
+	Lines  int
+	Level  int
+	Reason EndReason
+}
+
+// Subscribe registers a handler which is called with every event, in the order they happen
+// handlers are called synchronously while the game is being updated, so they shouldn't block or call back into the game
+// handlers should be subscribed before the game is started
+func (e *Engine) Subscribe(handler func(Event)) {
+	e.subscribers = append(e.subscribers, handler)
+}
+
+// SubscribeChannel sends every event to the channel, in the order they happen
+// the game is never blocked by a slow subscriber, so events are dropped if the channel is full
+func (e *Engine) SubscribeChannel(events chan<- Event) {
+	e.Subscribe(func(event Event) {
+		select {
+		case events <- event:
+		default:
+		}
+	})
+}
+
+// emit timestamps the event then records it in the stats and passes it to each subscriber
+func (e *Engine) emit(event Event) {
+	event.Time = e.elapsed
+	e.stats.record(event)
+	for _, handler := range e.subscribers {
+		handler(event)
+	}
+}
@@ -0,0 +1,103 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ShawnROGrady/gotris/internal/game/tetrimino"
+)
+
+var eventTests = map[string]struct {
+	options        []Option
+	linesCleared   int
+	advance        time.Duration
+	actions        []Action
+	expectedEvents []Event
+}{
+	"start": {
+		expectedEvents: []Event{
+			{Type: PieceSpawned, Piece: tetrimino.IPiece},
+		},
+	},
+	"line cleared": {
+		advance: 500 * time.Millisecond,
+		actions: []Action{MoveUp},
+		expectedEvents: []Event{
+			{Type: PieceSpawned, Piece: tetrimino.IPiece},
+			{Type: PieceLocked, Time: 500 * time.Millisecond, Piece: tetrimino.IPiece},
+			{Type: LinesCleared, Time: 500 * time.Millisecond, Lines: 1},
+			{Type: PieceSpawned, Time: 500 * time.Millisecond, Piece: tetrimino.IPiece},
+		},
+	},
+	"level up": {
+		linesCleared: 9,
+		actions:      []Action{MoveUp},
+		expectedEvents: []Event{
+			{Type: PieceSpawned, Piece: tetrimino.IPiece},
+			{Type: PieceLocked, Piece: tetrimino.IPiece},
+			{Type: LinesCleared, Lines: 1},
+			{Type: LevelUp, Level: 1},
+			{Type: PieceSpawned, Piece: tetrimino.IPiece},
+		},
+	},
+	"goal reached": {
+		options: []Option{WithMode(Mode{lineGoal: 1})},
+		actions: []Action{MoveUp, MoveUp},
+		expectedEvents: []Event{
+			{Type: PieceSpawned, Piece: tetrimino.IPiece},
+			{Type: PieceLocked, Piece: tetrimino.IPiece},
+			{Type: LinesCleared, Lines: 1},
+			{Type: GameOver, Reason: GoalReached},
+		},
+	},
+}
+
+func TestEvents(t *testing.T) {
+	for testName, test := range eventTests {
+		// with a width of 4 every horizontal 'I' piece clears a line
+		e := NewEngine(append([]Option{WithBoardSize(4, 20)}, test.options...)...)
+		e.newPieceSet = testNewSet(tetrimino.PieceConstructors[0])
+		initPieces := e.newPieceSet(boardWidth(e.board), boardHeight(e.board))
+		e.currentPiece, e.nextPieces = initPieces[0], initPieces[1:]
+		e.linesCleared = test.linesCleared
+
+		events := []Event{}
+		e.Subscribe(func(event Event) { events = append(events, event) })
+		e.Start()
+		e.Advance(test.advance)
+		for _, action := range test.actions {
+			e.Apply(action)
+		}
+
+		if len(events) != len(test.expectedEvents) {
+			t.Errorf("Unexpected number of events for test case '%s' [expected = %+v, actual = %+v]", testName, test.expectedEvents, events)
+			continue
+		}
+		for i := range events {
+			if events[i] != test.expectedEvents[i] {
+				t.Errorf("Unexpected event %d for test case '%s' [expected = %+v, actual = %+v]", i, testName, test.expectedEvents[i], events[i])
+			}
+		}
+	}
+}
+
+func TestSubscribeChannel(t *testing.T) {
+	var (
+		e      = NewEngine()
+		events = make(chan Event, 1)
+	)
+	e.SubscribeChannel(events)
+
+	// the channel is full after the first piece spawns, so the game over is dropped rather than blocking
+	e.Start()
+	e.End(TimeUp)
+
+	if event := <-events; event.Type != PieceSpawned {
+		t.Errorf("Unexpected event [expected = %s, actual = %s]", PieceSpawned, event.Type)
+	}
+	select {
+	case event := <-events:
+		t.Errorf("Unexpected event once the channel was full [event = %+v]", event)
+	default:
+	}
+}
@@ -1,8 +1,10 @@
 package game
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"runtime/debug"
 	"sync"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/ShawnROGrady/gotris/internal/game/board"
 	"github.com/ShawnROGrady/gotris/internal/game/tetrimino"
 	"github.com/ShawnROGrady/gotris/internal/inputreader"
+	"github.com/ShawnROGrady/gotris/internal/layout"
 )
 
 // Defaults for the game
@@ -17,48 +20,77 @@ const (
 	defaultColor = canvas.White
 )
 
-// Game is responsible for handling the game state
+// Game plays the game in a terminal, displaying the state of its engine and applying the user's input
 type Game struct {
-	inputreader   inputreader.InputReader
-	canvas        canvas.Canvas
-	board         *board.Board
-	currentPiece  tetrimino.Tetrimino
-	ghostPiece    tetrimino.Tetrimino
-	newPieceSet   func(width, height int) []tetrimino.Tetrimino
-	nextPieces    []tetrimino.Tetrimino
-	level         level
-	currentScore  int
-	linesCleared  int
-	debugMode     bool
-	disableGhost  bool
-	disableSide   bool
-	controlScheme ControlScheme
-	widthScale    int
-	gameCells     gameCells
-	color         canvas.Color
-	mutex         *sync.Mutex
+	*Engine
+	inputreader     inputreader.InputReader
+	ownReader       *inputreader.TermReader // the reader created by the game, which is closed once it's been run
+	clock           Clock
+	canvas          canvas.Canvas
+	highScore       int
+	disableGhost    bool
+	disableSide     bool
+	panels          []Panel
+	sidebarPosition SidebarPosition
+	controlScheme   ControlScheme
+	widthScale      int
+	halfBlocks      bool
+	monochrome      bool
+	gameCells       gameCells
+	color           canvas.Color
+	boxStyle        canvas.BoxStyle
+	maxFPS          int
+	termWidth       int
+	termHeight      int
+	layout          arrangement
+	sideStale       bool
+	mutex           *sync.Mutex
 }
 
 type gameCells struct {
 	nextPiece [][]canvas.Cell
 	score     [][]canvas.Cell
+	stats     [][]canvas.Cell
 	controls  [][]canvas.Cell
 }
 
 // New returns a new game with the specified specifications
+// unless another input reader is specified, the game reads from termReader until it ends
+// termReader is closed once the game ends if it's an io.Closer, so that no reads are left pending
 func New(termReader io.Reader, termWriter io.Writer, opts ...Option) *Game {
+	reader := inputreader.NewTermReader(termReader)
 	g := &Game{
-		inputreader:   inputreader.NewTermReader(termReader),
-		newPieceSet:   tetrimino.NewSet,
-		level:         0,
-		currentScore:  0,
-		linesCleared:  0,
+		Engine:        newEngine(),
+		inputreader:   reader,
+		clock:         RealTime(DefaultTickInterval),
 		widthScale:    board.DefaultWidthScale,
 		color:         defaultColor,
 		controlScheme: HomeRow(),
 		mutex:         &sync.Mutex{},
+		maxFPS:        DefaultMaxFPS,
+		panels:        DefaultPanels(),
 	}
 
+	boardOpts, canvasOpts := g.applyOptions(opts)
+	g.Subscribe(g.handleEvent)
+	if g.inputreader == reader {
+		g.ownReader = reader
+	}
+
+	// initialize the games canvas (what's rendered)
+	c := canvas.New(termWriter, canvasOpts...)
+	g.canvas = newGCanvas(c, g.maxFPS, g.debugMode)
+
+	// initialize the games board (used for game logic) and first pieces
+	g.init(boardOpts)
+
+	g.fitLayout()
+
+	return g
+}
+
+// applyOptions applies each of the options to the game, returning those which also apply to the board and canvas
+func (g *Game) applyOptions(opts []Option) ([]board.Option, []canvas.Option) {
 	var (
 		boardOpts  = []board.Option{}
 		canvasOpts = []canvas.Option{}
@@ -73,528 +105,289 @@ func New(termReader io.Reader, termWriter io.Writer, opts ...Option) *Game {
 			canvasOpts = append(canvasOpts, canvasOpt)
 		}
 	}
+	return boardOpts, canvasOpts
+}
 
-	// initialize the games canvas (what's rendered)
-	c := canvas.New(termWriter, canvasOpts...)
-	gCanvas := &gCanvas{
-		canvas:   c,
-		newCells: make(chan [][]canvas.Cell),
-		mut:      &sync.Mutex{},
-	}
-	g.canvas = gCanvas
+// Run plays the game until it ends, returning the result
+// if the context is cancelled first the game is stopped, and the result so far is returned along with the context's error
+// every goroutine started by the game has exited by the time Run returns,
+// which includes those reading input unless the reader was shared using WithInputReader
+// a panic in the game or any of its goroutines is returned as a PanicError, so the caller can restore the terminal
+func (g *Game) Run(ctx context.Context) (result Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = g.Result(), newPanicError(r)
+		}
+	}()
 
-	// initialize the games board (used for game logic)
-	board := board.New(boardOpts...)
-	g.board = board
+	if g.ownReader != nil {
+		// closed once the game's goroutines have stopped reading from it
+		defer g.ownReader.Close()
+	}
 
-	// initialize first pieces
-	initPieces := tetrimino.NewSet(boardWidth(board), boardHeight(board))
-	piece, pieceSet := initPieces[0], initPieces[1:]
-	g.currentPiece = piece
-	g.nextPieces = pieceSet
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	// deferred calls run in reverse order, so the goroutines are stopped before waiting on them
+	defer wg.Wait()
+	defer cancel()
 
-	return g
-}
+	// input which the game doesn't get to handle is returned to the reader, e.g. for the next game or screen using it
+	unread := func([]byte) {}
+	if unreader, ok := g.inputreader.(inputreader.Unreader); ok {
+		unread = unreader.Unread
+	}
 
-// Run takes care of the core game functionality
-func (g *Game) Run(done chan bool) (chan int, chan error) {
 	var (
-		runErr   = make(chan error)
-		endScore = make(chan int)
+		rawInput, readErr = g.inputreader.ReadInput(ctx)
+		input             = make(chan Action)
+		ticks             = make(chan time.Duration)
+		// buffered so a goroutine which panics never blocks
+		panics = make(chan error, 3)
 	)
-	controlMap := g.controlScheme.controlMap()
-
-	input, readErr := translateInput(done, g.inputreader, controlMap)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer recoverPanic(panics)
+		translateInput(ctx, rawInput, g.controlScheme.controlMap(), input, unread)
+	}()
 
-	// add initial piece to canvas
-	g.addPieceToBoard(g.currentPiece)
-	g.ghostPiece = g.findGhostPiece()
+	// add initial piece to the board
+	g.Start()
 
-	if !g.disableSide {
+	if g.layout.side != sideHidden {
 		// add initial sidebar cells
 		g.updateCells(g.board.Background())
 	}
 
 	// initialize the canvas
 	if err := g.canvas.Init(); err != nil {
-		runErr <- err
-		return endScore, runErr
+		return g.Result(), err
 	}
 
 	if gCanvas, ok := g.canvas.(*gCanvas); ok {
-		gCanvas.run(done)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer recoverPanic(panics)
+			gCanvas.run(ctx)
+		}()
 	}
 
 	// render initial canvas
 	g.canvas.UpdateCells(g.cells(g.board))
 	if err := g.canvas.Render(); err != nil {
-		runErr <- err
-		return endScore, runErr
+		return g.Result(), err
 	}
 
+	// the clock is started once the game is displayed, so no time passes beforehand
+	wg.Add(1)
 	go func() {
-		var gravity <-chan time.Time
-		if !g.debugMode {
-			// set initial gravity
-			gravity = time.After(g.level.gTime())
-		}
-		for {
-			select {
-			case err := <-readErr:
-				runErr <- err
-				return
-			case <-done:
-				return
-			case <-gravity:
-				if err := g.handleInput(moveDown, endScore); err != nil {
-					runErr <- err
-					return
-				}
-				if !g.debugMode {
-					gravity = time.After(g.level.gTime())
-				}
-			case in := <-input:
-				if g.debugMode {
-					fmt.Printf("User input: %s\n", in)
-				}
-
-				if err := g.handleInput(in, endScore); err != nil {
-					runErr <- err
-					return
-				}
-
-			}
-		}
+		defer wg.Done()
+		defer recoverPanic(panics)
+		g.clock.Run(ctx, ticks)
 	}()
-	return endScore, runErr
-}
-
-func (g *Game) addPieceToBoard(piece tetrimino.Tetrimino) {
-	var (
-		topL   = piece.ContainingBox().TopLeft
-		blocks = piece.Blocks()
-	)
-
-	for i, row := range blocks {
-		for j, block := range row {
-			if block == nil {
-				continue
-			}
-			x := topL.X + j
-			y := topL.Y - i
-
-			g.board.Blocks[y][x] = block
-		}
-	}
-}
 
-// pieceConflicts checks if the current piece is in an occupied space
-func (g *Game) pieceConflicts(oldTopL tetrimino.Coordinates, oldBlocks [][]*board.Block) bool {
-	var (
-		topL       = g.currentPiece.ContainingBox().TopLeft
-		blocks     = g.currentPiece.Blocks()
-		prevCoords = make(map[tetrimino.Coordinates]bool)
-	)
-
-	for i, row := range oldBlocks {
-		for j, block := range row {
-			if block == nil {
-				continue
+	for {
+		select {
+		case err := <-readErr:
+			return g.Result(), err
+		case err := <-panics:
+			return g.Result(), err
+		case <-ctx.Done():
+			return g.Result(), ctx.Err()
+		case d := <-ticks:
+			if err := g.advance(d); err != nil {
+				return g.Result(), err
 			}
-			x := oldTopL.X + j
-			y := oldTopL.Y - i
-			prevCoords[tetrimino.Coordinates{
-				X: x,
-				Y: y,
-			}] = true
-		}
-	}
-
-	for i, row := range blocks {
-		for j, block := range row {
-			if block == nil {
-				continue
+			if g.endReason != NotOver {
+				return g.Result(), nil
 			}
-			x := topL.X + j
-			y := topL.Y - i
-
-			if g.board.Blocks[y][x] != nil && !prevCoords[tetrimino.Coordinates{X: x, Y: y}] {
-				return true
+		case in := <-input:
+			if g.debugMode {
+				fmt.Printf("User input: %s\n", in)
 			}
-		}
-	}
 
-	return false
-}
-
-// pieceOutOfBounds checks if the piece is no longer in the bounds of the board
-// this can happen after a rotation
-func (g *Game) pieceOutOfBounds() bool {
-	var (
-		topL   = g.currentPiece.ContainingBox().TopLeft
-		blocks = g.currentPiece.Blocks()
-	)
-	for i, row := range blocks {
-		for j, block := range row {
-			if block == nil {
-				continue
+			if err := g.handleInput(in); err != nil {
+				return g.Result(), err
 			}
-			x := topL.X + j
-			y := topL.Y - i
-
-			// can't rotate due to horizontal constraints
-			if x < 0 || x > boardWidth(g.board)-1 {
-				return true
-			}
-
-			if y < 0 || y > boardHeight(g.board)-1 {
-				return true
+			if g.endReason != NotOver {
+				return g.Result(), nil
 			}
 		}
 	}
-	return false
 }
 
-// checks if the current piece is in the hidden row(s)
-func (g *Game) pieceAtTop() bool {
-	return g.currentPiece.YMax().Y > len(g.board.Blocks)-g.board.HiddenRows()-1
+// PanicError is returned by Run if the game panics
+type PanicError struct {
+	Value interface{} // the value passed to panic
+	Stack []byte      // the stack trace of the goroutine which panicked
 }
 
-// current piece is at minimum vertical position
-// either at bottom or on top of another piece
-func (g *Game) pieceAtBottom(piece tetrimino.Tetrimino) bool {
-	var (
-		topL        = piece.ContainingBox().TopLeft
-		blocks      = piece.Blocks()
-		pieceCoords = make(map[tetrimino.Coordinates]bool)
-	)
-
-	if piece.YMin().Y <= 0 {
-		return true
-	}
-
-	for i, row := range blocks {
-		for j, block := range row {
-			if block == nil {
-				continue
-			}
-			x := topL.X + j
-			y := topL.Y - i
-			pieceCoords[tetrimino.Coordinates{
-				X: x,
-				Y: y,
-			}] = true
-		}
-	}
+func newPanicError(value interface{}) PanicError {
+	return PanicError{Value: value, Stack: debug.Stack()}
+}
 
-	for i, row := range blocks {
-		for j, block := range row {
-			if block == nil {
-				continue
-			}
-			x := topL.X + j
-			y := topL.Y - i
+func (p PanicError) Error() string {
+	return fmt.Sprintf("panic: %v\n\n%s", p.Value, p.Stack)
+}
 
-			if g.board.Blocks[y-1][x] != nil && !pieceCoords[tetrimino.Coordinates{X: x, Y: y - 1}] {
-				return true
-			}
-		}
+// recoverPanic sends any panic to the channel as a PanicError, it must be deferred by the goroutine
+func recoverPanic(panics chan<- error) {
+	if r := recover(); r != nil {
+		panics <- newPanicError(r)
 	}
-
-	return false
 }
 
-func (g *Game) handleInput(input userInput, endScore chan int) error {
-	var (
-		topL     = g.currentPiece.ContainingBox().TopLeft
-		blocks   = g.currentPiece.Blocks()
-		canSlide = true
-	)
-
+// handleInput applies the action to the engine then renders the result
+func (g *Game) handleInput(input Action) error {
 	// should expect exclusive access when handling input
 	g.mutex.Lock()
 	defer g.mutex.Unlock()
 
-	g.movePiece(input)
-
-	if input == rotateLeft || input == rotateRight {
-		if g.pieceOutOfBounds() || g.pieceConflicts(topL, blocks) {
-			if !g.resolveRotation() {
-				// move back to original spot
-				if opposite := input.opposite(); opposite != ignore {
-					g.movePiece(opposite)
-					g.ghostPiece = g.findGhostPiece()
-					return nil
-				}
-			}
-		}
+	if g.layout.tooSmall {
+		// paused until the terminal is large enough to display the game
+		return nil
 	}
 
-	// new space already occupied
-	if (input == moveLeft || input == moveRight || (input == moveUp && g.debugMode)) && (g.pieceOutOfBounds() || g.pieceConflicts(topL, blocks)) {
-		// move back to original spot
-		if opposite := input.opposite(); opposite != ignore {
-			g.movePiece(opposite)
-			g.ghostPiece = g.findGhostPiece()
-			return nil
-		}
+	g.Apply(input)
+	if g.endReason != NotOver {
+		return g.endGame()
 	}
 
-	// piece was already at the bottom
-	if (!g.debugMode && input == moveUp) || g.pieceOutOfBounds() || g.pieceConflicts(topL, blocks) {
-		canSlide = false
-		if g.pieceOutOfBounds() || g.pieceConflicts(topL, blocks) {
-			if opposite := input.opposite(); opposite != ignore {
-				g.movePiece(opposite)
-				g.ghostPiece = g.findGhostPiece()
-			}
-		}
-	}
-	g.ghostPiece = g.findGhostPiece()
-
-	// clear cell where piece was
-	for i, row := range blocks {
-		for j, block := range row {
-			if block == nil {
-				continue
-			}
-			x := topL.X + j
-			y := topL.Y - i
-
-			g.board.Blocks[y][x] = nil
-		}
-	}
-
-	// update cell at pieces new position
-	g.addPieceToBoard(g.currentPiece)
-	g.ghostPiece = g.findGhostPiece()
-
-	// generate new current piece if at bottom or on top of another piece
-	if g.pieceAtBottom(g.currentPiece) && !canSlide {
-		// check if any rows can be cleared
-		linesCleared := g.board.ClearFullRows()
-		if linesCleared != 0 {
-			g.linesCleared += linesCleared
-			g.currentScore += g.level.linePoints(linesCleared)
-			newLevel := g.level.updatedLevel(g.linesCleared)
-			g.level = newLevel
-		}
-
-		if g.pieceAtTop() {
-			// still render game-over state
-			g.canvas.UpdateCells(g.cells(g.board))
-			if err := g.canvas.Render(); err != nil {
-				return err
-			}
-			endScore <- g.currentScore
-			return nil
-		}
-
-		g.currentPiece = g.nextPiece()
-		g.ghostPiece = g.findGhostPiece()
-
-		if !g.disableSide {
-			// update cells to include new next + updated score
-			g.updateCells(g.board.Background())
-		}
-
-		// add new piece to canvas
-		g.addPieceToBoard(g.currentPiece)
-		if g.pieceAtBottom(g.currentPiece) {
-			// new piece already at bottom -> game over
-			g.canvas.UpdateCells(g.cells(g.board))
-			if err := g.canvas.Render(); err != nil {
-				return err
-			}
-			endScore <- g.currentScore
-			return nil
-		}
-	}
-
-	if !g.disableGhost {
-		newBoard := g.boardWithGhost()
-		g.canvas.UpdateCells(g.cells(newBoard))
-	} else {
-		g.canvas.UpdateCells(g.cells(g.board))
-	}
+	g.updateSide()
+	g.canvas.UpdateCells(g.currentCells())
 
 	return g.canvas.Render()
 }
 
-func (g *Game) movePiece(input userInput) {
-	var (
-		piece = g.currentPiece
-	)
-
-	switch input {
-	case moveLeft:
-		piece.MoveLeft()
-	case moveDown:
-		piece.MoveDown()
-	case moveUp:
-		if g.debugMode {
-			piece.MoveUp()
-		} else {
-			// hard drop
-			g.ghostPiece.ToggleGhost()
-			g.currentPiece = g.ghostPiece
-			g.ghostPiece = nil
-		}
-	case moveRight:
-		piece.MoveRight()
-	case rotateLeft:
-		piece.RotateCounter()
-	case rotateRight:
-		piece.RotateClockwise()
-	}
-}
+// advance moves the engine forward in time then renders the result if anything changed
+func (g *Game) advance(d time.Duration) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
 
-func (g *Game) nextPiece() tetrimino.Tetrimino {
-	var nextPiece tetrimino.Tetrimino
-	if len(g.nextPieces) == 1 {
-		var (
-			boardWidth  = boardWidth(g.board)
-			boardHeight = boardHeight(g.board)
-		)
-		nextPiece = g.nextPieces[0]
-		g.nextPieces = g.newPieceSet(boardWidth, boardHeight)
-		return nextPiece
+	if g.layout.tooSmall {
+		// paused until the terminal is large enough to display the game
+		return nil
 	}
-	nextPiece, g.nextPieces = g.nextPieces[0], g.nextPieces[1:]
-	return nextPiece
-}
 
-func (g *Game) resolveRotation() bool {
 	var (
-		piece  = g.currentPiece
-		topL   = g.currentPiece.ContainingBox().TopLeft
-		blocks = g.currentPiece.Blocks()
+		ticks   = g.ticks
+		seconds = g.elapsed / time.Second
 	)
-
-	for _, rotationTest := range piece.RotationTests() {
-		rotationTest.ApplyTest()
-		if !(g.pieceOutOfBounds() || g.pieceConflicts(topL, blocks)) {
-			return true
-		}
-
-		rotationTest.RevertTest()
+	g.Advance(d)
+	if g.endReason != NotOver {
+		return g.endGame()
 	}
 
-	return false
-}
-
-func (g *Game) findGhostPiece() tetrimino.Tetrimino {
-	var (
-		ghost = g.currentPiece.SpawnGhost()
-	)
-
-	for !g.pieceAtBottom(ghost) {
-		ghost.MoveDown()
+	// the elapsed time is only displayed to the second
+	if g.showsPanel(StatsPanel) && g.elapsed/time.Second != seconds {
+		g.sideStale = true
+	}
+	if !g.updateSide() && g.ticks == ticks {
+		// nothing to display
+		return nil
 	}
 
-	return ghost
+	g.canvas.UpdateCells(g.currentCells())
+	return g.canvas.Render()
 }
 
-func pieceCoords(piece tetrimino.Tetrimino, boardBlocks [][]*board.Block) map[tetrimino.Coordinates]bool {
-	var (
-		topL        = piece.ContainingBox().TopLeft
-		blocks      = piece.Blocks()
-		pieceCoords = make(map[tetrimino.Coordinates]bool)
-	)
-
-	for i, row := range blocks {
-		for j, block := range row {
-			if block == nil {
-				continue
-			}
-			x := topL.X + j
-			y := topL.Y - i
-			pieceCoords[tetrimino.Coordinates{
-				X: x,
-				Y: y,
-			}] = true
-		}
+// handleEvent keeps track of whether the side bar is up to date
+// the next piece, score and stats all change when a piece is locked or spawned
+func (g *Game) handleEvent(event Event) {
+	if event.Type != GameOver {
+		g.sideStale = true
 	}
-	return pieceCoords
 }
 
-// the board with the ghost piece included
-// should NOT modify actual game board since ghost piece is irrelevant to game logic
-func (g *Game) boardWithGhost() *board.Board {
-	var (
-		ghost       = g.ghostPiece
-		ghostTopL   = ghost.ContainingBox().TopLeft
-		ghostBlocks = ghost.Blocks()
-		g2          = *g
-		newBoard    = *g2.board
-		newBlocks   [][]*board.Block
-	)
-
-	currentPieceCoords := pieceCoords(g.currentPiece, g.board.Blocks)
-
-	for i := range g.board.Blocks {
-		row := []*board.Block{}
-		for j := range g.board.Blocks[i] {
-			row = append(row, g.board.Blocks[i][j])
-		}
-		newBlocks = append(newBlocks, row)
+// updateSide updates the side bar cells if they're out of date, reporting whether they were updated
+func (g *Game) updateSide() bool {
+	stale := g.sideStale
+	g.sideStale = false
+	if !stale || g.layout.side == sideHidden {
+		return false
 	}
-	newBoard.Blocks = newBlocks
-
-	for i := range ghostBlocks {
-		for j, block := range ghostBlocks[i] {
-			if block == nil {
-				continue
-			}
-			x := ghostTopL.X + j
-			y := ghostTopL.Y - i
+	g.updateCells(g.board.Background())
+	return true
+}
 
-			if currentPieceCoords[tetrimino.Coordinates{X: x, Y: y}] {
-				// active piece should be displayed in case of conflict with ghost
-				continue
-			}
-			newBoard.Blocks[y][x] = block
-		}
+// currentCells are the cells for the current state of the game, including the ghost piece if enabled
+func (g *Game) currentCells() [][]canvas.Cell {
+	if !g.disableGhost && g.ghostPiece != nil {
+		return g.cells(g.boardWithGhost())
 	}
-	return &newBoard
+	return g.cells(g.board)
 }
 
 func (g *Game) updateCells(background canvas.Color) {
 	nextPiece := g.nextPieces[0]
 	formattedBlocks := centerBlocks(nextPiece.Blocks(), tetrimino.MaxWidth, tetrimino.MaxHeight)
-	nextPieceCells := canvas.Box(board.BlockGridCells(formattedBlocks, background, g.widthScale), "NEXT")
+	nextPieceCells := g.box(g.blockCells(board.BlockGridCells(formattedBlocks, background, g.layout.widthScale)), "NEXT")
 
-	currentScore := fmt.Sprintf("Score: %d\nLevel: %d", g.currentScore, g.level)
-	scoreCells := canvas.Box(canvas.CellsFromString(currentScore, g.color), "")
+	lines := fmt.Sprintf("%d", g.lines)
+	if g.mode.lineGoal != 0 {
+		lines = fmt.Sprintf("%d/%d", g.lines, g.mode.lineGoal)
+	}
+	currentScore := fmt.Sprintf("Score: %d\nLevel: %d\nLines: %s", g.currentScore, g.level, lines)
+	if g.highScore > 0 {
+		currentScore = fmt.Sprintf("Best: %d\n%s", g.highScore, currentScore)
+	}
+	scoreCells := g.box(canvas.CellsFromString(currentScore, g.color), "")
 
-	schemeCells := canvas.Box(canvas.CellsFromString(g.controlScheme.Description(), g.color), "CONTROLS")
+	schemeCells := g.box(canvas.CellsFromString(g.controlScheme.Description(), g.color), "CONTROLS")
+
+	var statsCells [][]canvas.Cell
+	if g.showsPanel(StatsPanel) {
+		statsCells = g.box(canvas.CellsFromString(g.Stats().String(), g.color), "STATS")
+	}
 
 	g.gameCells = gameCells{
 		nextPiece: nextPieceCells,
 		score:     scoreCells,
+		stats:     statsCells,
 		controls:  schemeCells,
 	}
 }
 
-func (g *Game) cells(b *board.Board) [][]canvas.Cell {
-	gameCells := canvas.Box(b.Cells(), "GAME")
+// usesHalfBlocks reports whether two rows of blocks are drawn in each row of the terminal
+// plain characters can only show a single block, so half blocks aren't used without colors
+func (g *Game) usesHalfBlocks() bool {
+	return g.halfBlocks && !g.monochrome
+}
 
-	if !g.disableSide {
-		nextPieceCells := g.gameCells.nextPiece
-		for i := range nextPieceCells {
-			gameCells[i] = append(gameCells[i], nextPieceCells[i]...)
-		}
+// blockCells packs the cells of a grid of blocks into half blocks if enabled
+func (g *Game) blockCells(cells [][]canvas.Cell) [][]canvas.Cell {
+	if g.usesHalfBlocks() {
+		return canvas.HalfBlocks(cells)
+	}
+	return cells
+}
 
-		scoreCells := g.gameCells.score
-		for i := range scoreCells {
-			gameCells[i+len(nextPieceCells)] = append(gameCells[i+len(nextPieceCells)], scoreCells[i]...)
-		}
+// box wraps the cells in a box drawn using the theme's style
+func (g *Game) box(inner [][]canvas.Cell, caption string) [][]canvas.Cell {
+	return canvas.StyledBox(inner, caption, g.boxStyle)
+}
 
-		schemeCells := g.gameCells.controls
-		for i := range schemeCells {
-			gameCells[i+len(nextPieceCells)+len(scoreCells)] = append(gameCells[i+len(nextPieceCells)+len(scoreCells)], schemeCells[i]...)
-		}
+func (g *Game) cells(b *board.Board) [][]canvas.Cell {
+	if g.layout.tooSmall {
+		return g.tooSmallCells()
 	}
 
-	return gameCells
+	var (
+		gameCells = g.box(g.blockCells(b.Cells()), "GAME")
+		panels    = g.panelCells()
+	)
+
+	switch g.layout.side {
+	case sideBeside:
+		if g.sidebarPosition == SidebarLeft {
+			// keep the panels next to the board
+			return layout.HStack(layout.Start, layout.VStack(layout.End, panels...), gameCells)
+		}
+		return layout.HStack(layout.Start, gameCells, layout.VStack(layout.Start, panels...))
+	case sideBottom:
+		return layout.VStack(layout.Start, gameCells, layout.HStack(layout.Start, panels...))
+	default:
+		return gameCells
+	}
 }
@@ -1,9 +1,13 @@
 package game
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
+	"runtime"
 	"sync"
 	"testing"
 	"time"
@@ -32,6 +36,7 @@ type testCanvas struct {
 }
 
 func (t *testCanvas) Init() error                          { return nil }
+func (t *testCanvas) Close() error                         { return nil }
 func (t *testCanvas) Render() error                        { return nil }
 func (t *testCanvas) UpdateCells(newCells [][]canvas.Cell) { t.cells = newCells }
 
@@ -39,16 +44,21 @@ func newTestGame(width, height, hiddenRows int, pieceSetConstructor func(width,
 	initPieces := pieceSetConstructor(width, height+hiddenRows)
 	piece, pieceSet := initPieces[0], initPieces[1:]
 	opts := []board.Option{board.WithWidth(width), board.WithHeight(height), board.WithHiddenRows(hiddenRows)}
-	return &Game{
-		board:         board.New(opts...),
-		currentPiece:  piece,
-		nextPieces:    pieceSet,
+	g := &Game{
+		Engine: &Engine{
+			board:        board.New(opts...),
+			currentPiece: piece,
+			nextPieces:   pieceSet,
+			newPieceSet:  pieceSetConstructor,
+			stats:        newStats(),
+		},
 		canvas:        &testCanvas{cells: [][]canvas.Cell{}},
-		newPieceSet:   pieceSetConstructor,
 		disableGhost:  false, // enabling ghost to catch potential nil-pointer/index-oob exceptions
 		controlScheme: HomeRow(),
 		mutex:         &sync.Mutex{},
 	}
+	g.Subscribe(g.handleEvent)
+	return g
 }
 
 func testNewSet(pieceConstructor tetrimino.PieceConstructor) func(width, height int) []tetrimino.Tetrimino {
@@ -357,16 +367,17 @@ func testPieceCoords(piece tetrimino.Tetrimino, testName string, testCase tetrim
 	return nil
 }
 
-func fillInputSequence(input userInput, count int) []userInput {
-	sequence := []userInput{}
+// fillInputSequence returns the input repeated count+1 times
+func fillInputSequence(input Action, count int) []Action {
+	sequence := []Action{}
 	for i := 0; i <= count; i++ {
 		sequence = append(sequence, input)
 	}
 	return sequence
 }
 
-func combineInputSequences(sequences ...[]userInput) []userInput {
-	inputSequence := []userInput{}
+func combineInputSequences(sequences ...[]Action) []Action {
+	inputSequence := []Action{}
 	for _, sequence := range sequences {
 		inputSequence = append(inputSequence, sequence...)
 	}
@@ -378,7 +389,7 @@ var handleInputTests = map[string]struct {
 	boardWidth            int
 	boardHeight           int
 	hiddenRows            int
-	inputSequence         []userInput
+	inputSequence         []Action
 	expectAtTop           bool
 	expectedPosition      tetriminoTestCase
 	expectedGhostPosition tetriminoTestCase
@@ -390,7 +401,7 @@ var handleInputTests = map[string]struct {
 		boardHeight:      20,
 		hiddenRows:       4,
 		expectAtTop:      true,
-		inputSequence:    fillInputSequence(moveDown, 22), // 21 to get to bottom, one to lock in place
+		inputSequence:    fillInputSequence(MoveDown, 22), // 21 to get to bottom, one to lock in place
 		// new piece will also be an "I" piece
 		expectedPosition: tetriminoTestCase{
 			expectedMaxY: tetriminoCoordTest{
@@ -437,8 +448,8 @@ var handleInputTests = map[string]struct {
 		hiddenRows:       4,
 		expectAtTop:      false,
 		inputSequence: combineInputSequences(
-			[]userInput{rotateLeft}, fillInputSequence(moveDown, 20), // rotate then move to bottom
-			[]userInput{rotateLeft, moveRight}, fillInputSequence(moveDown, 17), []userInput{moveLeft}, // rotate, move right, move down then attempt to move left
+			[]Action{RotateLeft}, fillInputSequence(MoveDown, 20), // rotate then move to bottom
+			[]Action{RotateLeft, MoveRight}, fillInputSequence(MoveDown, 17), []Action{MoveLeft}, // rotate, move right, move down then attempt to move left
 		),
 		// final move should fail
 		expectedPosition: tetriminoTestCase{
@@ -485,8 +496,8 @@ var handleInputTests = map[string]struct {
 		hiddenRows:       4,
 		expectAtTop:      false,
 		inputSequence: combineInputSequences(
-			[]userInput{rotateLeft}, fillInputSequence(moveRight, 4), // rotate then move to right
-			[]userInput{rotateLeft},
+			[]Action{RotateLeft}, fillInputSequence(MoveRight, 4), // rotate then move to right
+			[]Action{RotateLeft},
 		),
 		// rotation should succeed
 		expectedPosition: tetriminoTestCase{
@@ -533,8 +544,8 @@ var handleInputTests = map[string]struct {
 		hiddenRows:       4,
 		expectAtTop:      false,
 		inputSequence: combineInputSequences(
-			[]userInput{rotateLeft}, fillInputSequence(moveRight, 4), // rotate then move to right
-			[]userInput{rotateRight},
+			[]Action{RotateLeft}, fillInputSequence(MoveRight, 4), // rotate then move to right
+			[]Action{RotateRight},
 		),
 		// rotation should succeed
 		expectedPosition: tetriminoTestCase{
@@ -581,8 +592,8 @@ var handleInputTests = map[string]struct {
 		hiddenRows:       4,
 		expectAtTop:      false,
 		inputSequence: combineInputSequences(
-			[]userInput{rotateRight}, fillInputSequence(moveRight, 4), // rotate then move to right
-			[]userInput{rotateRight},
+			[]Action{RotateRight}, fillInputSequence(MoveRight, 4), // rotate then move to right
+			[]Action{RotateRight},
 		),
 		// rotation should succeed
 		expectedPosition: tetriminoTestCase{
@@ -629,8 +640,8 @@ var handleInputTests = map[string]struct {
 		hiddenRows:       4,
 		expectAtTop:      false,
 		inputSequence: combineInputSequences(
-			[]userInput{rotateRight}, fillInputSequence(moveRight, 4), // rotate then move to right
-			[]userInput{rotateLeft},
+			[]Action{RotateRight}, fillInputSequence(MoveRight, 4), // rotate then move to right
+			[]Action{RotateLeft},
 		),
 		// rotation should succeed
 		expectedPosition: tetriminoTestCase{
@@ -675,7 +686,7 @@ var handleInputTests = map[string]struct {
 		boardWidth:       10,
 		boardHeight:      20,
 		hiddenRows:       4,
-		inputSequence:    append(fillInputSequence(moveDown, 273)), // sum(x, 3, 23)
+		inputSequence:    fillInputSequence(MoveDown, 273), // sum(x, 3, 23)
 		expectAtTop:      true,
 		expectGameOver:   true,
 	},
@@ -684,7 +695,7 @@ var handleInputTests = map[string]struct {
 		boardWidth:       10,
 		boardHeight:      20,
 		hiddenRows:       4,
-		inputSequence:    append(fillInputSequence(moveUp, 10)),
+		inputSequence:    fillInputSequence(MoveUp, 10),
 		expectAtTop:      true,
 		expectGameOver:   true,
 	},
@@ -694,7 +705,7 @@ var handleInputTests = map[string]struct {
 		boardHeight:      20,
 		hiddenRows:       4,
 		expectAtTop:      false,
-		inputSequence:    []userInput{moveDown, moveRight, moveRight, moveRight, moveRight, moveRight, moveRight},
+		inputSequence:    []Action{MoveDown, MoveRight, MoveRight, MoveRight, MoveRight, MoveRight, MoveRight},
 		expectedPosition: tetriminoTestCase{
 			expectedMaxY: tetriminoCoordTest{
 				y: 22,
@@ -742,51 +753,37 @@ func TestHandleInput(t *testing.T) {
 		// have to initialize ghost piece
 		g.ghostPiece = g.findGhostPiece()
 
-		var (
-			endScore  = make(chan int)
-			handleErr = make(chan error)
-			inputOver = make(chan bool)
-			gameOver  = make(chan bool)
-		)
-
-		go func(gameOver chan bool) {
-			for _, input := range test.inputSequence {
-				select {
-				case <-gameOver:
-					return
-				default:
-					if err := g.handleInput(input, endScore); err != nil {
-						handleErr <- err
-					}
-				}
+		for _, input := range test.inputSequence {
+			if err := g.handleInput(input); err != nil {
+				t.Fatalf("Unexpected error handling user input for test case '%s': %s", testName, err)
+			}
+			if g.endReason != NotOver {
+				break
 			}
-			inputOver <- true
-		}(gameOver)
+		}
 
-		select {
-		case err := <-handleErr:
-			t.Fatalf("Unexpected error handling user input for test case '%s': %s", testName, err)
-		case score := <-endScore:
+		if g.endReason != NotOver {
 			if !test.expectGameOver {
-				t.Fatalf("Game unexpectedly over for test case '%s' (final score = %d)", testName, score)
-			}
-		case <-inputOver:
-			if test.expectGameOver {
-				t.Fatalf("Game unexpectedly not over for test case '%s' (current piece maxY = %v, minY = %v)", testName, g.currentPiece.YMax(), g.currentPiece.YMin())
-			}
-			// verify current piece coordinates
-			if err := testPieceCoords(g.currentPiece, testName, test.expectedPosition); err != nil {
-				t.Errorf("Current Piece: %s", err)
-			}
-			// verify ghost piece coordinates
-			if err := testPieceCoords(g.ghostPiece, testName, test.expectedGhostPosition); err != nil {
-				t.Errorf("Ghost Piece: %s", err)
+				t.Fatalf("Game unexpectedly over for test case '%s' (final score = %d)", testName, g.currentScore)
 			}
+			continue
+		}
+		if test.expectGameOver {
+			t.Fatalf("Game unexpectedly not over for test case '%s' (current piece maxY = %v, minY = %v)", testName, g.currentPiece.YMax(), g.currentPiece.YMin())
+		}
 
-			// check if piece at top
-			if test.expectAtTop && !g.pieceAtTop() {
-				t.Errorf("Piece unexpectedly not at top for test case '%s' (maxY = %v, minY = %v)", testName, g.currentPiece.YMax(), g.currentPiece.YMin())
-			}
+		// verify current piece coordinates
+		if err := testPieceCoords(g.currentPiece, testName, test.expectedPosition); err != nil {
+			t.Errorf("Current Piece: %s", err)
+		}
+		// verify ghost piece coordinates
+		if err := testPieceCoords(g.ghostPiece, testName, test.expectedGhostPosition); err != nil {
+			t.Errorf("Ghost Piece: %s", err)
+		}
+
+		// check if piece at top
+		if test.expectAtTop && !g.pieceAtTop() {
+			t.Errorf("Piece unexpectedly not at top for test case '%s' (maxY = %v, minY = %v)", testName, g.currentPiece.YMax(), g.currentPiece.YMin())
 		}
 	}
 }
@@ -820,7 +817,7 @@ var boardWithGhostTests = map[string]struct {
 	boardWidth       int
 	boardHeight      int
 	hiddenRows       int
-	inputSequence    []userInput
+	inputSequence    []Action
 	overrideDiff     func(row, column int, block *board.Block) *board.Block
 }{
 	"new i piece no moves": {
@@ -837,7 +834,7 @@ var boardWithGhostTests = map[string]struct {
 		boardHeight:      20,
 		hiddenRows:       4,
 		inputSequence: combineInputSequences(
-			[]userInput{rotateLeft}, fillInputSequence(moveDown, 17),
+			[]Action{RotateLeft}, fillInputSequence(MoveDown, 17),
 		),
 		overrideDiff: func(row, column int, block *board.Block) *board.Block {
 			// the third and fourth blocks in the fourth column will conflict
@@ -847,6 +844,7 @@ var boardWithGhostTests = map[string]struct {
 					return &board.Block{
 						Color:       canvas.Cyan,
 						Transparent: true,
+						Label:       tetrimino.IPiece.String(),
 					}
 				}
 			}
@@ -863,12 +861,8 @@ func TestBoardWithGhost(t *testing.T) {
 		// have to initialize ghost piece
 		g.ghostPiece = g.findGhostPiece()
 
-		var (
-			endScore = make(chan int)
-		)
-
 		for _, input := range test.inputSequence {
-			if err := g.handleInput(input, endScore); err != nil {
+			if err := g.handleInput(input); err != nil {
 				t.Fatalf("Unexpected error handling input for test case '%s'", testName)
 			}
 		}
@@ -951,6 +945,7 @@ var runTests = map[string]struct {
 	currentLevel   level
 	inputs         []string
 	inputDelay     time.Duration
+	ticks          int // the number of gravity intervals to advance the clock by once every input has been written
 	expectedScore  int
 	expectGameOver bool
 }{
@@ -959,11 +954,10 @@ var runTests = map[string]struct {
 		expectGameOver: true,
 		inputDelay:     1 * time.Millisecond,
 	},
-	"max level, unhandled input until end": {
+	"max level, gravity until end": {
 		currentLevel:   29,
-		inputs:         fillStringSlice("r", 2*273), // 2 * sum(x, 3, 23), need to double to account for 'sliding' piece
+		ticks:          2 * 273, // 2 * sum(x, 3, 23), need to double to account for 'sliding' piece
 		expectGameOver: true,
-		inputDelay:     22 * time.Millisecond,
 	},
 	"clear one line, lvl 0": {
 		currentLevel: 0,
@@ -983,7 +977,7 @@ var runTests = map[string]struct {
 func TestRun(t *testing.T) {
 	for testName, test := range runTests {
 		var (
-			done                 = make(chan bool)
+			ctx, cancel          = context.WithCancel(context.Background())
 			inReader, inWriter   = io.Pipe()
 			outReader, outWriter = io.Pipe()
 		)
@@ -993,7 +987,7 @@ func TestRun(t *testing.T) {
 			defer outReader.Close()
 			for {
 				select {
-				case <-done:
+				case <-ctx.Done():
 					return
 				default:
 					// TODO: verify written output matches what we expect
@@ -1007,7 +1001,8 @@ func TestRun(t *testing.T) {
 			}
 		}()
 
-		g := New(inReader, outWriter, WithControlScheme(HomeRow()))
+		clock := NewManualClock()
+		g := New(inReader, outWriter, WithControlScheme(HomeRow()), WithClock(clock))
 		g.level = test.currentLevel
 
 		// Using exclusively 'I' pieces for easy testing
@@ -1018,47 +1013,56 @@ func TestRun(t *testing.T) {
 		g.currentPiece, g.nextPieces = piece, pieceSet
 		g.newPieceSet = pieceSetConstructor
 
-		finalScore, runErr := g.Run(done)
-
 		go func() {
 			defer func() {
 				inWriter.Close()
-				time.Sleep(10 * time.Millisecond) // give ReadInput go routine enough time to read last input following 'done'
-				close(done)
+				time.Sleep(10 * time.Millisecond) // give ReadInput go routine enough time to read last input before cancelling
+				cancel()
 			}()
 			for _, in := range test.inputs {
 				_, err := inWriter.Write([]byte(in))
+				if err == io.ErrClosedPipe {
+					// the game has ended, and closed its reader
+					return
+				}
 				if err != nil {
 					log.Panicf("Error writing input for test case '%s': %s", testName, err)
 					return
 				}
 				time.Sleep(test.inputDelay)
 			}
+			for i := 0; i < test.ticks; i++ {
+				if !clock.Advance(test.currentLevel.gTime()) {
+					// the game has ended
+					return
+				}
+			}
 		}()
 
-		select {
-		case score := <-finalScore:
+		// the game is stopped once every input has been written, unless it ends first
+		result, err := g.Run(ctx)
+		switch err {
+		case nil:
 			if !test.expectGameOver {
 				t.Errorf("Game unexpectedly over after handling inputs for test case '%s'", testName)
 			}
-			if score != test.expectedScore {
-				t.Errorf("Unexpected final score for test case '%s' [expected = %d, actual = %d]", testName, test.expectedScore, score)
+			if result.Score != test.expectedScore {
+				t.Errorf("Unexpected final score for test case '%s' [expected = %d, actual = %d]", testName, test.expectedScore, result.Score)
+			}
+			if result.Reason == NotOver {
+				t.Errorf("Unexpected end reason for test case '%s' [actual = %s]", testName, result.Reason)
 			}
 
 			// wait for goroutine writing inputs to complete (might still be running due to input delay)
-			<-done
-		case <-done:
+			<-ctx.Done()
+		case context.Canceled:
 			if test.expectGameOver {
 				t.Errorf("Game unexpectedly not over after handling inputs for test case '%s'", testName)
 			}
-			g.mutex.Lock()
-			score := g.currentScore
-			g.mutex.Unlock()
-
-			if score != test.expectedScore {
-				t.Errorf("Unexpected current score for test case '%s' [expected = %d, actual = %d]", testName, test.expectedScore, score)
+			if result.Score != test.expectedScore {
+				t.Errorf("Unexpected current score for test case '%s' [expected = %d, actual = %d]", testName, test.expectedScore, result.Score)
 			}
-		case err := <-runErr:
+		default:
 			t.Errorf("Unexpected error running game for test case '%s: %s'", testName, err)
 		}
 		// NOTE: uncomment the below to print the state of the game at the end of the test
@@ -1071,9 +1075,125 @@ func TestRun(t *testing.T) {
 	}
 }
 
+// idleReader never receives any input, and doesn't start any goroutines
+type idleReader struct{}
+
+func (idleReader) ReadInput(ctx context.Context) (<-chan []byte, <-chan error) {
+	return make(chan []byte), make(chan error)
+}
+
+type failingCanvas struct{ testCanvas }
+
+var errCanvasInit = errors.New("canvas init failed")
+
+func (f *failingCanvas) Init() error { return errCanvasInit }
+
+var runStoppedTests = map[string]struct {
+	canvas      canvas.Canvas
+	termReader  bool // read from a terminal (a pipe) rather than sharing a reader
+	timeout     time.Duration
+	expectedErr error
+}{
+	"cancelled": {
+		timeout:     20 * time.Millisecond,
+		expectedErr: context.DeadlineExceeded,
+	},
+	"cancelled while reading from the terminal": {
+		termReader:  true,
+		timeout:     20 * time.Millisecond,
+		expectedErr: context.DeadlineExceeded,
+	},
+	"canvas init fails": {
+		canvas:      &failingCanvas{},
+		timeout:     500 * time.Millisecond,
+		expectedErr: errCanvasInit,
+	},
+}
+
+func TestRunStopped(t *testing.T) {
+	for testName, test := range runStoppedTests {
+		goroutines := runtime.NumGoroutine()
+
+		var g *Game
+		if test.termReader {
+			inReader, inWriter := io.Pipe()
+			defer inWriter.Close()
+			g = New(inReader, ioutil.Discard)
+		} else {
+			g = New(nil, ioutil.Discard, WithInputReader(idleReader{}))
+		}
+		if test.canvas != nil {
+			g.canvas = test.canvas
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), test.timeout)
+
+		result, err := g.Run(ctx)
+		cancel()
+		if err != test.expectedErr {
+			t.Errorf("Unexpected error for test case '%s' [expected = %v, actual = %v]", testName, test.expectedErr, err)
+		}
+		if result.Reason != NotOver {
+			t.Errorf("Unexpected end reason for test case '%s' [expected = %s, actual = %s]", testName, NotOver, result.Reason)
+		}
+
+		// every goroutine started by the game should have exited
+		if running := runtime.NumGoroutine(); running > goroutines {
+			t.Errorf("Unexpected goroutines still running for test case '%s' [before = %d, after = %d]", testName, goroutines, running)
+		}
+	}
+}
+
+type panickingCanvas struct{ testCanvas }
+
+func (p *panickingCanvas) Init() error { panic("canvas init panicked") }
+
+// panickingClock panics once started, which happens in a separate goroutine
+type panickingClock struct{}
+
+func (panickingClock) Run(ctx context.Context, ticks chan<- time.Duration) { panic("clock panicked") }
+
+var runPanicsTests = map[string]struct {
+	canvas        canvas.Canvas
+	clock         Clock
+	expectedValue interface{}
+}{
+	"panic in the game loop": {
+		canvas:        &panickingCanvas{},
+		clock:         NewManualClock(),
+		expectedValue: "canvas init panicked",
+	},
+	"panic in a goroutine": {
+		canvas:        &testCanvas{},
+		clock:         panickingClock{},
+		expectedValue: "clock panicked",
+	},
+}
+
+func TestRunPanics(t *testing.T) {
+	for testName, test := range runPanicsTests {
+		g := New(nil, ioutil.Discard, WithInputReader(idleReader{}), WithClock(test.clock))
+		g.canvas = test.canvas
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_, err := g.Run(ctx)
+		cancel()
+
+		panicErr, ok := err.(PanicError)
+		if !ok {
+			t.Errorf("Unexpected error for test case '%s' [expected = PanicError, actual = %v]", testName, err)
+			continue
+		}
+		if panicErr.Value != test.expectedValue {
+			t.Errorf("Unexpected panic value for test case '%s' [expected = %v, actual = %v]", testName, test.expectedValue, panicErr.Value)
+		}
+	}
+}
+
 func BenchmarkRun(b *testing.B) {
 	var (
-		done                 = make(chan bool)
+		ctx, cancel          = context.WithCancel(context.Background())
+		finished             = make(chan error, 1)
 		inReader, inWriter   = io.Pipe()
 		outReader, outWriter = io.Pipe()
 	)
@@ -1083,7 +1203,7 @@ func BenchmarkRun(b *testing.B) {
 		defer outReader.Close()
 		for {
 			select {
-			case <-done:
+			case <-ctx.Done():
 				return
 			default:
 				buf := make([]byte, 128)
@@ -1106,7 +1226,10 @@ func BenchmarkRun(b *testing.B) {
 	g.currentPiece, g.nextPieces = piece, pieceSet
 	g.newPieceSet = pieceSetConstructor
 
-	endScore, runErr := g.Run(done)
+	go func() {
+		_, err := g.Run(ctx)
+		finished <- err
+	}()
 
 	for n := 0; n < b.N; n++ {
 		var (
@@ -1130,16 +1253,17 @@ func BenchmarkRun(b *testing.B) {
 		}()
 
 		select {
-		case err := <-runErr:
-			b.Fatalf("Error running game: %s", err)
-		case <-endScore:
+		case err := <-finished:
+			if err != nil {
+				b.Fatalf("Error running game: %s", err)
+			}
 			log.Printf("game over: %d", n)
 		case err := <-writeErr:
 			b.Errorf("Error writing input: %s", err)
 		case <-written:
 		}
 	}
-	close(done)
+	cancel()
 	inWriter.Close()
 }
 
@@ -0,0 +1,125 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/ShawnROGrady/gotris/internal/canvas"
+	"github.com/ShawnROGrady/gotris/internal/game/board"
+	"github.com/ShawnROGrady/gotris/internal/layout"
+)
+
+// sidePlacement describes where the side bar (next piece, score, and controls) is displayed relative to the board
+type sidePlacement int
+
+const (
+	sideBeside sidePlacement = iota // to the left or right, depending on the sidebar position
+
+	sideBottom
+	sideHidden
+)
+
+func (s sidePlacement) String() string {
+	placementDescriptions := map[sidePlacement]string{
+		sideBeside: "beside",
+		sideBottom: "bottom",
+		sideHidden: "hidden",
+	}
+
+	return placementDescriptions[s]
+}
+
+// arrangement describes how the game is arranged on the terminal
+type arrangement struct {
+	widthScale int
+	side       sidePlacement
+	tooSmall   bool
+}
+
+// Resize re-arranges the game to fit in a terminal of the specified size, then re-renders it
+// if the game can't fit at all a message is displayed instead, and the game is paused until it can
+func (g *Game) Resize(width, height int) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.termWidth, g.termHeight = width, height
+	g.fitLayout()
+
+	// the previous frame was likely garbled by the terminal re-wrapping it
+	if err := g.canvas.Init(); err != nil {
+		return err
+	}
+	g.canvas.UpdateCells(g.currentCells())
+	return g.canvas.Render()
+}
+
+// fitLayout picks the largest width scale and most complete side bar placement which fit the terminal
+// a terminal size of 0 means the size is unknown, in which case the preferred layout is always used
+func (g *Game) fitLayout() {
+	preferredSide := sideBeside
+	if !g.showSide() {
+		preferredSide = sideHidden
+	}
+	g.setLayout(arrangement{widthScale: g.preferredWidthScale(), side: preferredSide})
+
+	if g.termWidth == 0 || g.termHeight == 0 {
+		return
+	}
+
+	for _, candidate := range g.candidateLayouts() {
+		g.setLayout(candidate)
+		cells := g.cells(g.board)
+		if len(cells) <= g.termHeight && layout.Width(cells) <= g.termWidth {
+			return
+		}
+	}
+
+	g.setLayout(arrangement{widthScale: 1, side: sideHidden, tooSmall: true})
+}
+
+// candidateLayouts lists the possible layouts in order of preference
+// a larger width scale is preferred over showing the side bar, since the board is harder to play when squashed
+func (g *Game) candidateLayouts() []arrangement {
+	sides := []sidePlacement{sideBeside, sideBottom, sideHidden}
+	if !g.showSide() {
+		sides = []sidePlacement{sideHidden}
+	}
+
+	candidates := []arrangement{}
+	for scale := g.preferredWidthScale(); scale >= 1; scale-- {
+		for _, side := range sides {
+			candidates = append(candidates, arrangement{widthScale: scale, side: side})
+		}
+	}
+	return candidates
+}
+
+// preferredWidthScale is the width scale used if the terminal is large enough
+// half blocks are already square, so their width is never scaled
+func (g *Game) preferredWidthScale() int {
+	if g.usesHalfBlocks() {
+		return 1
+	}
+	return g.widthScale
+}
+
+func (g *Game) setLayout(l arrangement) {
+	g.layout = l
+	board.WithWidthScale(l.widthScale).ApplyToBoard(g.board)
+	if l.side != sideHidden {
+		g.updateCells(g.board.Background())
+	}
+}
+
+// tooSmallCells is displayed instead of the game when the terminal is too small to fit it
+func (g *Game) tooSmallCells() [][]canvas.Cell {
+	var (
+		minWidth = boardWidth(g.board) + 2
+		rows     = boardHeight(g.board) - g.board.HiddenRows()
+	)
+	if g.usesHalfBlocks() {
+		rows = (rows + 1) / 2
+	}
+	minHeight := rows + 2
+	message := fmt.Sprintf("Terminal too small\nneed: %dx%d\nhave: %dx%d", minWidth, minHeight, g.termWidth, g.termHeight)
+	return canvas.CellsFromString(message, g.color)
+}
@@ -0,0 +1,225 @@
+package game
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ShawnROGrady/gotris/internal/canvas"
+	"github.com/ShawnROGrady/gotris/internal/layout"
+)
+
+var fitLayoutTests = map[string]struct {
+	options        []Option
+	expectedLayout arrangement
+}{
+	"unknown size": {
+		expectedLayout: arrangement{widthScale: 2, side: sideBeside},
+	},
+	"unknown size without side": {
+		options:        []Option{WithoutSide()},
+		expectedLayout: arrangement{widthScale: 2, side: sideHidden},
+	},
+	"large terminal": {
+		options:        []Option{WithTerminalSize(80, 24)},
+		expectedLayout: arrangement{widthScale: 2, side: sideBeside},
+	},
+	"narrow terminal": {
+		options:        []Option{WithTerminalSize(38, 32)},
+		expectedLayout: arrangement{widthScale: 2, side: sideBottom},
+	},
+	"narrow and short terminal": {
+		options:        []Option{WithTerminalSize(30, 22)},
+		expectedLayout: arrangement{widthScale: 2, side: sideHidden},
+	},
+	"very narrow terminal": {
+		options:        []Option{WithTerminalSize(15, 22)},
+		expectedLayout: arrangement{widthScale: 1, side: sideHidden},
+	},
+	"very narrow terminal without side": {
+		options:        []Option{WithTerminalSize(15, 22), WithoutSide()},
+		expectedLayout: arrangement{widthScale: 1, side: sideHidden},
+	},
+	"terminal too short": {
+		options:        []Option{WithTerminalSize(80, 10)},
+		expectedLayout: arrangement{widthScale: 1, side: sideHidden, tooSmall: true},
+	},
+	"half blocks": {
+		options:        []Option{WithTerminalSize(80, 24), WithHalfBlocks()},
+		expectedLayout: arrangement{widthScale: 1, side: sideBeside},
+	},
+	"half blocks fit a large board in a short terminal": {
+		options:        []Option{WithTerminalSize(30, 16), WithBoardSize(20, 24), WithHalfBlocks()},
+		expectedLayout: arrangement{widthScale: 1, side: sideHidden},
+	},
+	"half blocks ignored without colors": {
+		options:        []Option{WithTerminalSize(80, 24), WithHalfBlocks(), WithColorProfile(canvas.Monochrome)},
+		expectedLayout: arrangement{widthScale: 2, side: sideBeside},
+	},
+	"small board with tall side bar": {
+		options:        []Option{WithTerminalSize(80, 24), WithBoardSize(8, 12)},
+		expectedLayout: arrangement{widthScale: 2, side: sideBeside},
+	},
+}
+
+func TestFitLayout(t *testing.T) {
+	for testName, test := range fitLayoutTests {
+		var b bytes.Buffer
+
+		g := New(&b, &b, test.options...)
+		if g.layout != test.expectedLayout {
+			t.Errorf("Unexpected layout for test case '%s' [expected = %+v, actual = %+v]", testName, test.expectedLayout, g.layout)
+		}
+
+		cells := g.cells(g.board)
+		if g.termWidth != 0 && !g.layout.tooSmall && (len(cells) > g.termHeight || layout.Width(cells) > g.termWidth) {
+			t.Errorf("Unexpected cell dimensions for test case '%s' [terminal = %dx%d, cells = %dx%d]", testName, g.termWidth, g.termHeight, layout.Width(cells), len(cells))
+		}
+	}
+}
+
+func TestResize(t *testing.T) {
+	var b bytes.Buffer
+
+	g := New(&b, &b, WithTerminalSize(10, 10))
+	if !g.layout.tooSmall {
+		t.Fatalf("Unexpected layout, expected the terminal to be too small [layout = %+v]", g.layout)
+	}
+	if text := cellsText(g.currentCells()); !strings.Contains(text, "Terminal too small") {
+		t.Errorf("Unexpected cells for a terminal which is too small [text = %q]", text)
+	}
+
+	// input is ignored while the terminal is too small
+	initialPosition := g.currentPiece.ContainingBox().TopLeft
+	if err := g.handleInput(MoveDown); err != nil {
+		t.Fatalf("Unexpected error handling input: %s", err)
+	}
+	if position := g.currentPiece.ContainingBox().TopLeft; position != initialPosition {
+		t.Errorf("Unexpected piece movement while the terminal is too small [expected = %v, actual = %v]", initialPosition, position)
+	}
+
+	if err := g.Resize(80, 24); err != nil {
+		t.Fatalf("Unexpected error resizing: %s", err)
+	}
+	expectedLayout := arrangement{widthScale: 2, side: sideBeside}
+	if g.layout != expectedLayout {
+		t.Errorf("Unexpected layout after resize [expected = %+v, actual = %+v]", expectedLayout, g.layout)
+	}
+	if text := cellsText(g.currentCells()); strings.Contains(text, "Terminal too small") {
+		t.Errorf("Unexpected cells after resize [text = %q]", text)
+	}
+}
+
+func cellsText(cells [][]canvas.Cell) string {
+	var b strings.Builder
+	for _, row := range cells {
+		for _, cell := range row {
+			if textCell, ok := cell.(*canvas.TextCell); ok {
+				b.WriteString(textCell.Text)
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+var panelTests = map[string]struct {
+	options         []Option
+	expectedSide    sidePlacement
+	expectedLeft    bool
+	expectedOrder   []string // text identifying each panel, in the order the panels are displayed
+	expectedMissing []string
+}{
+	"default": {
+		options:       []Option{WithTerminalSize(80, 30)},
+		expectedSide:  sideBeside,
+		expectedOrder: []string{"NEXT", "Score:", "CONTROLS"},
+	},
+	"left": {
+		options:       []Option{WithTerminalSize(80, 30), WithSidebarPosition(SidebarLeft)},
+		expectedSide:  sideBeside,
+		expectedLeft:  true,
+		expectedOrder: []string{"NEXT", "Score:", "CONTROLS"},
+	},
+	"reordered and hidden": {
+		options:         []Option{WithTerminalSize(80, 30), WithSidebarPosition(SidebarLeft), WithPanels(ControlsPanel, NextPanel)},
+		expectedSide:    sideBeside,
+		expectedLeft:    true,
+		expectedOrder:   []string{"CONTROLS", "NEXT"},
+		expectedMissing: []string{"Score:"},
+	},
+	"bottom": {
+		options:       []Option{WithTerminalSize(30, 40), WithPanels(ScorePanel, NextPanel)},
+		expectedSide:  sideBottom,
+		expectedOrder: []string{"Score:", "NEXT"},
+	},
+	"stats": {
+		options:       []Option{WithTerminalSize(80, 50), WithPanels(NextPanel, StatsPanel)},
+		expectedSide:  sideBeside,
+		expectedOrder: []string{"NEXT", "STATS", "Pieces:"},
+	},
+	"no panels": {
+		options:         []Option{WithTerminalSize(80, 30), WithPanels()},
+		expectedSide:    sideHidden,
+		expectedMissing: []string{"NEXT", "Score:", "CONTROLS"},
+	},
+}
+
+func TestPanels(t *testing.T) {
+	for testName, test := range panelTests {
+		var b bytes.Buffer
+
+		g := New(&b, &b, test.options...)
+		if g.layout.side != test.expectedSide {
+			t.Errorf("Unexpected side bar placement for test case '%s' [expected = %s, actual = %s]", testName, test.expectedSide, g.layout.side)
+			continue
+		}
+
+		cells := g.cells(g.board)
+		for _, text := range test.expectedMissing {
+			if row, _ := findText(cells, text); row != -1 {
+				t.Errorf("Unexpected panel '%s' for test case '%s'", text, testName)
+			}
+		}
+
+		gameRow, gameColumn := findText(cells, "GAME")
+		prevRow, prevColumn := -1, -1
+		for _, text := range test.expectedOrder {
+			row, column := findText(cells, text)
+			if row == -1 {
+				t.Errorf("Missing panel '%s' for test case '%s'", text, testName)
+				continue
+			}
+
+			switch test.expectedSide {
+			case sideBeside:
+				if (column < gameColumn) != test.expectedLeft || row <= prevRow {
+					t.Errorf("Unexpected position of panel '%s' for test case '%s' [row = %d, column = %d, board column = %d]", text, testName, row, column, gameColumn)
+				}
+			case sideBottom:
+				if row <= gameRow || column <= prevColumn {
+					t.Errorf("Unexpected position of panel '%s' for test case '%s' [row = %d, column = %d, board row = %d]", text, testName, row, column, gameRow)
+				}
+			}
+			prevRow, prevColumn = row, column
+		}
+	}
+}
+
+// findText returns the position of the first occurrence of the text, or -1 if it isn't displayed
+func findText(cells [][]canvas.Cell, text string) (row, column int) {
+	for i, r := range cells {
+		var b strings.Builder
+		for _, cell := range r {
+			if textCell, ok := cell.(*canvas.TextCell); ok {
+				b.WriteString(textCell.Text)
+			} else {
+				b.WriteByte('#')
+			}
+		}
+		if j := strings.Index(b.String(), text); j != -1 {
+			return i, j
+		}
+	}
+	return -1, -1
+}
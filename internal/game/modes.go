@@ -0,0 +1,71 @@
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+// the available game modes
+const (
+	MarathonMode = "marathon"
+	SprintMode   = "sprint"
+	UltraMode    = "ultra"
+)
+
+// Mode determines how a game can end, other than by topping out
+type Mode struct {
+	name      string
+	lineGoal  int
+	timeLimit time.Duration
+}
+
+func (m Mode) String() string {
+	if m.name == "" {
+		return MarathonMode
+	}
+	return m.name
+}
+
+// Marathon represents the mode where the game continues until topping out
+func Marathon() Mode {
+	return Mode{name: MarathonMode}
+}
+
+// Sprint represents the mode where the game ends after clearing 40 lines
+func Sprint() Mode {
+	return Mode{
+		name:     SprintMode,
+		lineGoal: 40,
+	}
+}
+
+// Ultra represents the mode where the game ends after 2 minutes
+func Ultra() Mode {
+	return Mode{
+		name:      UltraMode,
+		timeLimit: 2 * time.Minute,
+	}
+}
+
+// ModeFromName retrieves the mode associated with the specified name
+func ModeFromName(name string) (Mode, error) {
+	switch name {
+	case MarathonMode:
+		return Marathon(), nil
+	case SprintMode:
+		return Sprint(), nil
+	case UltraMode:
+		return Ultra(), nil
+	default:
+		return Mode{}, fmt.Errorf("unrecognized mode: '%s'", name)
+	}
+}
+
+// AvailableModes represents the set of available game modes
+func AvailableModes() []Mode {
+	return []Mode{Marathon(), Sprint(), Ultra()}
+}
+
+func (m Mode) goalReached(lines int) bool {
+	return m.lineGoal != 0 && lines >= m.lineGoal
+}
@@ -0,0 +1,99 @@
+package game
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/ShawnROGrady/gotris/internal/game/tetrimino"
+)
+
+var modeFromNameTests = map[string]struct {
+	name        string
+	expectedErr bool
+	expected    Mode
+}{
+	"marathon": {
+		name:     MarathonMode,
+		expected: Marathon(),
+	},
+	"sprint": {
+		name:     SprintMode,
+		expected: Sprint(),
+	},
+	"ultra": {
+		name:     UltraMode,
+		expected: Ultra(),
+	},
+	"unrecognized": {
+		name:        "zen",
+		expectedErr: true,
+	},
+}
+
+func TestModeFromName(t *testing.T) {
+	for testName, test := range modeFromNameTests {
+		m, err := ModeFromName(test.name)
+		if err != nil {
+			if !test.expectedErr {
+				t.Errorf("Unexpected error for test case '%s': %s", testName, err)
+			}
+			continue
+		}
+		if test.expectedErr {
+			t.Errorf("Unexpectedly no error for test case '%s'", testName)
+		}
+		if m != test.expected {
+			t.Errorf("Unexpected mode for test case '%s' [expected = %#v, actual = %#v]", testName, test.expected, m)
+		}
+	}
+}
+
+func TestLineGoal(t *testing.T) {
+	// with a width of 4 every horizontal 'I' piece clears a line
+	g := newTestGame(4, 20, 4, testNewSet(tetrimino.PieceConstructors[0]))
+	g.mode = Mode{lineGoal: 3}
+	g.addPieceToBoard(g.currentPiece)
+	g.ghostPiece = g.findGhostPiece()
+
+	for i := 0; i < 3; i++ {
+		if g.EndReason() != NotOver {
+			t.Fatalf("Game unexpectedly over after clearing %d lines", g.lines)
+		}
+		if err := g.handleInput(MoveUp); err != nil {
+			t.Fatalf("Unexpected error handling user input: %s", err)
+		}
+	}
+
+	if g.EndReason() == NotOver {
+		t.Fatalf("Game unexpectedly not over after clearing %d lines", g.lines)
+	}
+	if g.EndReason() != GoalReached {
+		t.Errorf("Unexpected end reason [expected = %s, actual = %s]", GoalReached, g.EndReason())
+	}
+}
+
+func TestTimeLimit(t *testing.T) {
+	var (
+		ctx, cancel        = context.WithTimeout(context.Background(), 500*time.Millisecond)
+		inReader, inWriter = io.Pipe()
+	)
+	defer inWriter.Close()
+	defer cancel()
+
+	g := New(inReader, ioutil.Discard, WithMode(Mode{timeLimit: 10 * time.Millisecond}))
+	g.canvas = &testCanvas{}
+
+	result, err := g.Run(ctx)
+	if err == context.DeadlineExceeded {
+		t.Fatalf("Game unexpectedly not over after time limit")
+	}
+	if err != nil {
+		t.Fatalf("Unexpected error running game: %s", err)
+	}
+	if result.Reason != TimeUp {
+		t.Errorf("Unexpected end reason [expected = %s, actual = %s]", TimeUp, result.Reason)
+	}
+}
@@ -3,6 +3,9 @@ package game
 import (
 	"github.com/ShawnROGrady/gotris/internal/canvas"
 	"github.com/ShawnROGrady/gotris/internal/game/board"
+	"github.com/ShawnROGrady/gotris/internal/game/tetrimino"
+	"github.com/ShawnROGrady/gotris/internal/inputreader"
+	"github.com/ShawnROGrady/gotris/internal/theme"
 )
 
 // Option represents a game option
@@ -77,6 +80,97 @@ func (w withDebugMode) ApplyToCanvas(c *canvas.TermCanvas) {
 	canvas.WithDebugMode().ApplyToCanvas(c)
 }
 
+// WithTerminalSize returns an option that specifies the size of the terminal the game is rendered to
+// the width scale and side bar placement are then adjusted so the game fits, see Resize
+func WithTerminalSize(width, height int) Option {
+	return withTerminalSize{width: width, height: height}
+}
+
+type withTerminalSize struct {
+	width  int
+	height int
+}
+
+func (w withTerminalSize) Apply(g *Game) {
+	g.termWidth, g.termHeight = w.width, w.height
+}
+
+// WithMaxFPS returns an option that limits how many frames are rendered per second
+// a value of 0 removes the limit, although frames are still dropped if updated faster than they can be rendered
+func WithMaxFPS(fps int) Option {
+	return withMaxFPS(fps)
+}
+
+type withMaxFPS int
+
+func (w withMaxFPS) Apply(g *Game) {
+	g.maxFPS = int(w)
+}
+
+// WithoutAltScreen returns an option that specifies that the canvas should render to the main screen
+// e.g. when displaying output that should remain after exiting
+func WithoutAltScreen() Option {
+	return withoutAltScreen{}
+}
+
+type withoutAltScreen struct{}
+
+func (w withoutAltScreen) Apply(g *Game) {}
+
+func (w withoutAltScreen) ApplyToCanvas(c *canvas.TermCanvas) {
+	canvas.WithoutAltScreen().ApplyToCanvas(c)
+}
+
+// WithTheme returns an option that specifies the colors of the pieces, board, borders and text
+func WithTheme(t theme.Theme) Option {
+	return withTheme(t)
+}
+
+type withTheme theme.Theme
+
+func (w withTheme) Apply(g *Game) {
+	g.color = w.Text
+	g.boxStyle = theme.Theme(w).BoxStyle()
+	g.pieceColors = w.Pieces
+}
+
+func (w withTheme) ApplyToBoard(b *board.Board) {
+	board.WithBackground(w.Background).ApplyToBoard(b)
+	board.WithGhostStyle(w.Ghost).ApplyToBoard(b)
+}
+
+func (w withTheme) ApplyToCanvas(c *canvas.TermCanvas) {
+	canvas.WithBackground(w.Background).ApplyToCanvas(c)
+}
+
+// WithColorProfile returns an option that specifies the colors supported by the terminal
+// colors which aren't supported (e.g. RGB colors on a 256 color terminal) are converted to the closest supported color
+func WithColorProfile(p canvas.ColorProfile) Option {
+	return withColorProfile(p)
+}
+
+type withColorProfile canvas.ColorProfile
+
+func (w withColorProfile) Apply(g *Game) {
+	g.monochrome = canvas.ColorProfile(w) == canvas.Monochrome
+}
+
+func (w withColorProfile) ApplyToCanvas(c *canvas.TermCanvas) {
+	canvas.WithColorProfile(canvas.ColorProfile(w)).ApplyToCanvas(c)
+}
+
+// WithHalfBlocks returns an option that draws two rows of blocks in each row of the terminal using half block characters
+// this keeps blocks square without doubling their width, so larger boards fit in smaller terminals
+func WithHalfBlocks() Option {
+	return withHalfBlocks{}
+}
+
+type withHalfBlocks struct{}
+
+func (w withHalfBlocks) Apply(g *Game) {
+	g.halfBlocks = true
+}
+
 // WithoutSide returns an option that disables the side bar
 func WithoutSide() Option {
 	return withoutSide{}
@@ -88,6 +182,29 @@ func (w withoutSide) Apply(g *Game) {
 	g.disableSide = true
 }
 
+// WithPanels returns an option that specifies which panels are displayed in the side bar, in order
+// the side bar is hidden if no panels are specified
+func WithPanels(panels ...Panel) Option {
+	return withPanels(panels)
+}
+
+type withPanels []Panel
+
+func (w withPanels) Apply(g *Game) {
+	g.panels = []Panel(w)
+}
+
+// WithSidebarPosition returns an option that specifies which side of the board the side bar is displayed on
+func WithSidebarPosition(p SidebarPosition) Option {
+	return withSidebarPosition(p)
+}
+
+type withSidebarPosition SidebarPosition
+
+func (w withSidebarPosition) Apply(g *Game) {
+	g.sidebarPosition = SidebarPosition(w)
+}
+
 // WithDimensions returns an option that specifies the dimensions of the board and canvas
 func WithDimensions(d dimensions) Option {
 	return d
@@ -140,3 +257,85 @@ func (w withInitialLevel) Apply(g *Game) {
 	g.level = level(w)
 	g.linesCleared = int(w) * 10 // allow level to increase as expected
 }
+
+// WithPartialLockOut returns an option that ends the game if a piece locks partially above the visible field
+func WithPartialLockOut() Option {
+	return withPartialLockOut{}
+}
+
+type withPartialLockOut struct{}
+
+func (w withPartialLockOut) Apply(g *Game) {
+	g.partialLockOut = true
+}
+
+// WithInputReader returns an option that specifies the reader used for user input
+// this allows a single reader to be shared across multiple games
+func WithInputReader(reader inputreader.InputReader) Option {
+	return withInputReader{reader: reader}
+}
+
+type withInputReader struct {
+	reader inputreader.InputReader
+}
+
+func (w withInputReader) Apply(g *Game) {
+	g.inputreader = w.reader
+}
+
+// WithClock returns an option that specifies the clock driving the game (default RealTime(DefaultTickInterval))
+func WithClock(clock Clock) Option {
+	return withClock{clock: clock}
+}
+
+type withClock struct {
+	clock Clock
+}
+
+func (w withClock) Apply(g *Game) {
+	g.clock = w.clock
+}
+
+// WithMode returns an option that specifies the game mode
+func WithMode(m Mode) Option {
+	return withMode(m)
+}
+
+type withMode Mode
+
+func (w withMode) Apply(g *Game) {
+	g.mode = Mode(w)
+}
+
+// WithSeed returns an option that specifies the seed used to generate the order of the pieces
+// games with the same seed get the same sequence of pieces
+func WithSeed(seed int64) Option {
+	return withSeed(seed)
+}
+
+type withSeed int64
+
+func (w withSeed) Apply(g *Game) {
+	g.seed = int64(w)
+	g.newPieceSet = tetrimino.SeededSets(int64(w))
+}
+
+// WithBoardSize returns an option that specifies the width and height of the board
+func WithBoardSize(width, height int) Option {
+	return dimensions{
+		width:      width,
+		height:     height,
+		widthScale: board.DefaultWidthScale,
+	}
+}
+
+// WithHighScore returns an option that specifies the current best score, which is displayed in the side bar
+func WithHighScore(score int) Option {
+	return withHighScore(score)
+}
+
+type withHighScore int
+
+func (w withHighScore) Apply(g *Game) {
+	g.highScore = int(w)
+}
@@ -3,9 +3,13 @@ package game
 import (
 	"bytes"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/ShawnROGrady/gotris/internal/canvas"
+	"github.com/ShawnROGrady/gotris/internal/game/tetrimino"
+	"github.com/ShawnROGrady/gotris/internal/inputreader"
+	"github.com/ShawnROGrady/gotris/internal/theme"
 )
 
 var optionTests = map[string]struct {
@@ -159,8 +163,164 @@ var optionTests = map[string]struct {
 			checkInitLevel(10),
 		},
 	},
+	"with input reader": {
+		options: []Option{
+			WithInputReader(testInputReader),
+		},
+		pass: []func(g *Game) error{
+			checkControlScheme(HomeRow()),
+			checkWithoutGhost(false),
+			checkBackground(canvas.White),
+			checkColor(canvas.White),
+			checkDebugMode(false),
+			checkWithoutSide(false),
+			checkWidthScale(2),
+			checkWidth(10),
+			checkHeight(24), // includes hidden rows
+			checkHiddenRows(4),
+			checkInitLevel(0),
+			checkInputReader(testInputReader),
+		},
+	},
+	"with sprint mode and board size 8x16": {
+		options: []Option{
+			WithMode(Sprint()),
+			WithBoardSize(8, 16),
+		},
+		pass: []func(g *Game) error{
+			checkControlScheme(HomeRow()),
+			checkWithoutGhost(false),
+			checkBackground(canvas.White),
+			checkColor(canvas.White),
+			checkDebugMode(false),
+			checkWithoutSide(false),
+			checkWidthScale(2),
+			checkWidth(8),
+			checkHeight(20), // includes hidden rows
+			checkHiddenRows(4),
+			checkInitLevel(0),
+			checkMode(Sprint()),
+		},
+	},
+	"with high score": {
+		options: []Option{
+			WithHighScore(1200),
+		},
+		pass: []func(g *Game) error{
+			checkControlScheme(HomeRow()),
+			checkWithoutGhost(false),
+			checkBackground(canvas.White),
+			checkColor(canvas.White),
+			checkDebugMode(false),
+			checkWithoutSide(false),
+			checkWidthScale(2),
+			checkWidth(10),
+			checkHeight(24), // includes hidden rows
+			checkHiddenRows(4),
+			checkInitLevel(0),
+			checkHighScore(1200),
+		},
+	},
+	"with max fps": {
+		options: []Option{
+			WithMaxFPS(30),
+		},
+		pass: []func(g *Game) error{
+			checkControlScheme(HomeRow()),
+			checkWithoutGhost(false),
+			checkBackground(canvas.White),
+			checkColor(canvas.White),
+			checkDebugMode(false),
+			checkWithoutSide(false),
+			checkWidthScale(2),
+			checkWidth(10),
+			checkHeight(24), // includes hidden rows
+			checkHiddenRows(4),
+			checkInitLevel(0),
+			checkMaxFPS(30),
+		},
+	},
+	"with theme": {
+		options: []Option{
+			WithTheme(theme.Solarized()),
+		},
+		pass: []func(g *Game) error{
+			checkControlScheme(HomeRow()),
+			checkWithoutGhost(false),
+			checkBackground(theme.Solarized().Background),
+			checkColor(theme.Solarized().Text),
+			checkDebugMode(false),
+			checkWithoutSide(false),
+			checkWidthScale(2),
+			checkWidth(10),
+			checkHeight(24), // includes hidden rows
+			checkHiddenRows(4),
+			checkInitLevel(0),
+			checkPieceColors(theme.Solarized()),
+		},
+	},
+	"with terminal size": {
+		options: []Option{
+			WithTerminalSize(80, 24),
+		},
+		pass: []func(g *Game) error{
+			checkControlScheme(HomeRow()),
+			checkWithoutGhost(false),
+			checkBackground(canvas.White),
+			checkColor(canvas.White),
+			checkDebugMode(false),
+			checkWithoutSide(false),
+			checkWidthScale(2),
+			checkWidth(10),
+			checkHeight(24), // includes hidden rows
+			checkHiddenRows(4),
+			checkInitLevel(0),
+			checkTerminalSize(80, 24),
+		},
+	},
+	"with half blocks": {
+		options: []Option{
+			WithHalfBlocks(),
+		},
+		pass: []func(g *Game) error{
+			checkControlScheme(HomeRow()),
+			checkWithoutGhost(false),
+			checkBackground(canvas.White),
+			checkColor(canvas.White),
+			checkDebugMode(false),
+			checkWithoutSide(false),
+			checkWidthScale(2),
+			checkWidth(10),
+			checkHeight(24), // includes hidden rows
+			checkHiddenRows(4),
+			checkInitLevel(0),
+			checkHalfBlocks(true),
+		},
+	},
+	"with panels": {
+		options: []Option{
+			WithPanels(ScorePanel, NextPanel),
+			WithSidebarPosition(SidebarLeft),
+		},
+		pass: []func(g *Game) error{
+			checkControlScheme(HomeRow()),
+			checkWithoutGhost(false),
+			checkBackground(canvas.White),
+			checkColor(canvas.White),
+			checkDebugMode(false),
+			checkWithoutSide(false),
+			checkWidthScale(2),
+			checkWidth(10),
+			checkHeight(24), // includes hidden rows
+			checkHiddenRows(4),
+			checkInitLevel(0),
+			checkPanels([]Panel{ScorePanel, NextPanel}, SidebarLeft),
+		},
+	},
 }
 
+var testInputReader = inputreader.NewTermReader(nil)
+
 func TestOptions(t *testing.T) {
 	for testName, test := range optionTests {
 		var b bytes.Buffer
@@ -237,6 +397,27 @@ func checkWidthScale(expected int) func(g *Game) error {
 	}
 }
 
+func checkHalfBlocks(expected bool) func(g *Game) error {
+	return func(g *Game) error {
+		if g.halfBlocks != expected {
+			return fmt.Errorf("unexpected halfBlocks [expected = %v, actual = %v]", expected, g.halfBlocks)
+		}
+		return nil
+	}
+}
+
+func checkPanels(expected []Panel, expectedPosition SidebarPosition) func(g *Game) error {
+	return func(g *Game) error {
+		if !reflect.DeepEqual(g.panels, expected) {
+			return fmt.Errorf("unexpected panels [expected = %v, actual = %v]", expected, g.panels)
+		}
+		if g.sidebarPosition != expectedPosition {
+			return fmt.Errorf("unexpected sidebarPosition [expected = %s, actual = %s]", expectedPosition, g.sidebarPosition)
+		}
+		return nil
+	}
+}
+
 func checkWidth(expected int) func(g *Game) error {
 	return func(g *Game) error {
 		boardWidth := boardWidth(g.board)
@@ -275,3 +456,68 @@ func checkInitLevel(expected level) func(g *Game) error {
 		return nil
 	}
 }
+
+func checkInputReader(expected inputreader.InputReader) func(g *Game) error {
+	return func(g *Game) error {
+		if g.inputreader != expected {
+			return fmt.Errorf("unexpected inputreader [expected = %p, actual = %p]", expected, g.inputreader)
+		}
+		return nil
+	}
+}
+
+func checkMode(expected Mode) func(g *Game) error {
+	return func(g *Game) error {
+		if g.mode != expected {
+			return fmt.Errorf("unexpected mode [expected = %s, actual = %s]", expected, g.mode)
+		}
+		return nil
+	}
+}
+
+func checkHighScore(expected int) func(g *Game) error {
+	return func(g *Game) error {
+		if g.highScore != expected {
+			return fmt.Errorf("unexpected high score [expected = %d, actual = %d]", expected, g.highScore)
+		}
+		return nil
+	}
+}
+
+func checkMaxFPS(expected int) func(g *Game) error {
+	return func(g *Game) error {
+		if g.maxFPS != expected {
+			return fmt.Errorf("unexpected max fps [expected = %d, actual = %d]", expected, g.maxFPS)
+		}
+		return nil
+	}
+}
+
+func checkTerminalSize(expectedWidth, expectedHeight int) func(g *Game) error {
+	return func(g *Game) error {
+		if g.termWidth != expectedWidth || g.termHeight != expectedHeight {
+			return fmt.Errorf("unexpected terminal size [expected = %dx%d, actual = %dx%d]", expectedWidth, expectedHeight, g.termWidth, g.termHeight)
+		}
+		return nil
+	}
+}
+
+func checkPieceColors(t theme.Theme) func(g *Game) error {
+	return func(g *Game) error {
+		pieces := append([]tetrimino.Tetrimino{g.currentPiece}, g.nextPieces...)
+		for _, piece := range pieces {
+			expected := t.Pieces[piece.Kind()]
+			for _, row := range piece.Blocks() {
+				for _, block := range row {
+					if block != nil && block.Color != expected {
+						return fmt.Errorf("unexpected color for %s piece [expected = %v, actual = %v]", piece.Kind(), expected, block.Color)
+					}
+				}
+			}
+		}
+		if g.boxStyle != t.BoxStyle() {
+			return fmt.Errorf("unexpected box style [expected = %+v, actual = %+v]", t.BoxStyle(), g.boxStyle)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,111 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ShawnROGrady/gotris/internal/canvas"
+)
+
+// Panel is one of the boxes displayed in the side bar
+type Panel int
+
+// the available panels
+const (
+	NextPanel Panel = iota
+	ScorePanel
+	ControlsPanel
+	StatsPanel
+)
+
+// Panels returns every panel
+func Panels() []Panel {
+	return []Panel{NextPanel, ScorePanel, ControlsPanel, StatsPanel}
+}
+
+// DefaultPanels returns the panels displayed unless others are specified, in order
+func DefaultPanels() []Panel {
+	return []Panel{NextPanel, ScorePanel, ControlsPanel}
+}
+
+func (p Panel) String() string {
+	panelNames := map[Panel]string{
+		NextPanel:     "next",
+		ScorePanel:    "score",
+		ControlsPanel: "controls",
+		StatsPanel:    "stats",
+	}
+
+	return panelNames[p]
+}
+
+// PanelFromName returns the panel with the specified name
+func PanelFromName(name string) (Panel, error) {
+	for _, p := range Panels() {
+		if p.String() == strings.ToLower(name) {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("unrecognized panel: '%s'", name)
+}
+
+// SidebarPosition is the side of the board the side bar is displayed on, if there is room for it
+type SidebarPosition int
+
+// the available side bar positions
+const (
+	SidebarRight SidebarPosition = iota
+	SidebarLeft
+)
+
+func (s SidebarPosition) String() string {
+	positionNames := map[SidebarPosition]string{
+		SidebarRight: "right",
+		SidebarLeft:  "left",
+	}
+
+	return positionNames[s]
+}
+
+// SidebarPositionFromName returns the side bar position with the specified name
+func SidebarPositionFromName(name string) (SidebarPosition, error) {
+	for _, s := range []SidebarPosition{SidebarRight, SidebarLeft} {
+		if s.String() == strings.ToLower(name) {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("unrecognized sidebar position: '%s'", name)
+}
+
+// showSide reports whether the side bar should be displayed if there is room for it
+func (g *Game) showSide() bool {
+	return !g.disableSide && len(g.panels) != 0
+}
+
+// panelCells returns the cells of each of the selected panels, in order
+func (g *Game) panelCells() [][][]canvas.Cell {
+	cells := [][][]canvas.Cell{}
+	for _, p := range g.panels {
+		switch p {
+		case NextPanel:
+			cells = append(cells, g.gameCells.nextPiece)
+		case ScorePanel:
+			cells = append(cells, g.gameCells.score)
+		case ControlsPanel:
+			cells = append(cells, g.gameCells.controls)
+		case StatsPanel:
+			cells = append(cells, g.gameCells.stats)
+		}
+	}
+	return cells
+}
+
+// showsPanel reports whether the panel is one of the selected panels
+func (g *Game) showsPanel(panel Panel) bool {
+	for _, p := range g.panels {
+		if p == panel {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPanelFromName(t *testing.T) {
+	for _, expected := range Panels() {
+		panel, err := PanelFromName(strings.ToUpper(expected.String()))
+		if err != nil {
+			t.Errorf("Unexpected error finding panel '%s': %s", expected, err)
+		} else if panel != expected {
+			t.Errorf("Unexpected panel [expected = %s, actual = %s]", expected, panel)
+		}
+	}
+
+	if _, err := PanelFromName("hold"); err == nil {
+		t.Errorf("Unexpectedly no error finding panel 'hold'")
+	}
+}
+
+func TestSidebarPositionFromName(t *testing.T) {
+	for _, expected := range []SidebarPosition{SidebarRight, SidebarLeft} {
+		position, err := SidebarPositionFromName(strings.ToUpper(expected.String()))
+		if err != nil {
+			t.Errorf("Unexpected error finding sidebar position '%s': %s", expected, err)
+		} else if position != expected {
+			t.Errorf("Unexpected sidebar position [expected = %s, actual = %s]", expected, position)
+		}
+	}
+
+	if _, err := SidebarPositionFromName("top"); err == nil {
+		t.Errorf("Unexpectedly no error finding sidebar position 'top'")
+	}
+}
@@ -0,0 +1,50 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Result is the outcome of a game
+type Result struct {
+	Score    int           `json:"score"`
+	Lines    int           `json:"lines"`
+	Level    int           `json:"level"`
+	Duration time.Duration `json:"duration"`
+	Stats    Stats         `json:"stats"`
+	Reason   EndReason     `json:"reason"`
+	Seed     int64         `json:"seed"` // passing this to WithSeed plays the same pieces again
+}
+
+// Result returns the result of the game so far, or of the whole game once it has ended
+func (e *Engine) Result() Result {
+	stats := e.Stats()
+	return Result{
+		Score:    e.currentScore,
+		Lines:    e.lines,
+		Level:    int(e.level),
+		Duration: stats.Elapsed,
+		Stats:    stats,
+		Reason:   e.endReason,
+		Seed:     e.seed,
+	}
+}
+
+// String is a summary of the result, one value per line
+func (r Result) String() string {
+	return fmt.Sprintf("Reason: %s\nScore: %d\nLevel: %d\nLines: %d\nSeed: %d\n%s", r.Reason, r.Score, r.Level, r.Lines, r.Seed, r.Stats)
+}
+
+// MarshalJSON encodes the result, with the duration in seconds rather than nanoseconds
+func (r Result) MarshalJSON() ([]byte, error) {
+	// the alias doesn't have any methods, so it doesn't recursively call MarshalJSON
+	type result Result
+	return json.Marshal(struct {
+		result
+		Duration float64 `json:"duration"`
+	}{
+		result:   result(r),
+		Duration: r.Duration.Seconds(),
+	})
+}
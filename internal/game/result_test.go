@@ -0,0 +1,73 @@
+package game
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ShawnROGrady/gotris/internal/game/tetrimino"
+)
+
+func testResult() Result {
+	stats := newStats()
+	stats.Elapsed = 90 * time.Second
+	stats.recordPiece(tetrimino.IPiece)
+	stats.recordPiece(tetrimino.IPiece)
+	stats.recordPiece(tetrimino.TPiece)
+	stats.recordClear(1)
+
+	return Result{
+		Score:    40,
+		Lines:    1,
+		Level:    2,
+		Duration: stats.Elapsed,
+		Stats:    stats,
+		Reason:   BlockOut,
+		Seed:     42,
+	}
+}
+
+func TestResultString(t *testing.T) {
+	text := testResult().String()
+	for _, expected := range []string{"Reason: block out", "Score: 40", "Level: 2", "Lines: 1", "Seed: 42", "Time: 1:30", "Pieces: 3"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Missing '%s' from result [text = %q]", expected, text)
+		}
+	}
+}
+
+func TestResultJSON(t *testing.T) {
+	encoded, err := json.Marshal(testResult())
+	if err != nil {
+		t.Fatalf("Unexpected error encoding result: %s", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unexpected error decoding result: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"score":    40.0,
+		"lines":    1.0,
+		"level":    2.0,
+		"duration": 90.0,
+		"reason":   "block out",
+		"seed":     42.0,
+	}
+	for key, value := range expected {
+		if decoded[key] != value {
+			t.Errorf("Unexpected value of '%s' [expected = %v, actual = %v, json = %s]", key, value, decoded[key], encoded)
+		}
+	}
+
+	stats, ok := decoded["stats"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Missing stats [json = %s]", encoded)
+	}
+	pieceCounts, ok := stats["piece-counts"].(map[string]interface{})
+	if !ok || pieceCounts["I"] != 2.0 || pieceCounts["T"] != 1.0 {
+		t.Errorf("Unexpected piece counts [json = %s]", encoded)
+	}
+}
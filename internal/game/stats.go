@@ -0,0 +1,127 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ShawnROGrady/gotris/internal/game/tetrimino"
+)
+
+// Stats tracks how a game has been played so far
+type Stats struct {
+	Elapsed     time.Duration          `json:"-"`      // encoded as the duration of the result
+	Pieces      int                    `json:"pieces"` // the number of pieces locked in place
+	PieceCounts map[tetrimino.Kind]int `json:"piece-counts"`
+	Singles     int                    `json:"singles"`
+	Doubles     int                    `json:"doubles"`
+	Triples     int                    `json:"triples"`
+	Tetrises    int                    `json:"tetrises"`
+}
+
+func newStats() Stats {
+	return Stats{PieceCounts: map[tetrimino.Kind]int{}}
+}
+
+// Lines is the total number of lines cleared
+func (s Stats) Lines() int {
+	return s.Singles + 2*s.Doubles + 3*s.Triples + 4*s.Tetrises
+}
+
+// PiecesPerSecond is the average number of pieces locked each second
+func (s Stats) PiecesPerSecond() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Pieces) / s.Elapsed.Seconds()
+}
+
+// LinesPerMinute is the average number of lines cleared each minute
+func (s Stats) LinesPerMinute() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Lines()) / s.Elapsed.Minutes()
+}
+
+// TetrisRate is the fraction of lines which were cleared by a tetris
+func (s Stats) TetrisRate() float64 {
+	if s.Lines() == 0 {
+		return 0
+	}
+	return float64(4*s.Tetrises) / float64(s.Lines())
+}
+
+// record updates the stats with the event
+func (s *Stats) record(event Event) {
+	switch event.Type {
+	case PieceLocked:
+		s.recordPiece(event.Piece)
+	case LinesCleared:
+		s.recordClear(event.Lines)
+	}
+}
+
+func (s *Stats) recordPiece(kind tetrimino.Kind) {
+	s.Pieces++
+	s.PieceCounts[kind]++
+}
+
+func (s *Stats) recordClear(lines int) {
+	switch lines {
+	case 1:
+		s.Singles++
+	case 2:
+		s.Doubles++
+	case 3:
+		s.Triples++
+	case 4:
+		s.Tetrises++
+	}
+}
+
+func (s Stats) String() string {
+	lines := []string{
+		fmt.Sprintf("Time: %s", formatElapsed(s.Elapsed)),
+		fmt.Sprintf("Pieces: %d", s.Pieces),
+		fmt.Sprintf("PPS: %.2f", s.PiecesPerSecond()),
+		fmt.Sprintf("LPM: %.1f", s.LinesPerMinute()),
+	}
+
+	// two kinds per line, like the NES statistics
+	kinds := tetrimino.Kinds()
+	for i := 0; i < len(kinds); i += 2 {
+		line := fmt.Sprintf("%s: %-3d", kinds[i], s.PieceCounts[kinds[i]])
+		if i+1 < len(kinds) {
+			line += fmt.Sprintf(" %s: %d", kinds[i+1], s.PieceCounts[kinds[i+1]])
+		}
+		lines = append(lines, strings.TrimSpace(line))
+	}
+
+	lines = append(lines,
+		fmt.Sprintf("Singles: %d", s.Singles),
+		fmt.Sprintf("Doubles: %d", s.Doubles),
+		fmt.Sprintf("Triples: %d", s.Triples),
+		fmt.Sprintf("Tetrises: %d", s.Tetrises),
+		fmt.Sprintf("Tetris rate: %.0f%%", 100*s.TetrisRate()),
+	)
+	return strings.Join(lines, "\n")
+}
+
+// formatElapsed formats the duration as minutes and seconds (e.g. 1:05)
+func formatElapsed(d time.Duration) string {
+	seconds := int(d / time.Second)
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// Stats returns the statistics for the game so far, or for the whole game once it has ended
+func (e *Engine) Stats() Stats {
+	stats := e.stats
+	stats.PieceCounts = map[tetrimino.Kind]int{}
+	for kind, count := range e.stats.PieceCounts {
+		stats.PieceCounts[kind] = count
+	}
+
+	stats.Elapsed = e.elapsed
+	return stats
+}
@@ -0,0 +1,103 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ShawnROGrady/gotris/internal/game/tetrimino"
+)
+
+var statsTests = map[string]struct {
+	stats              Stats
+	expectedLines      int
+	expectedPPS        float64
+	expectedLPM        float64
+	expectedTetrisRate float64
+}{
+	"new game": {
+		stats: newStats(),
+	},
+	"no time elapsed": {
+		stats:         Stats{Pieces: 3, Singles: 1},
+		expectedLines: 1,
+	},
+	"mixed clears": {
+		stats:              Stats{Elapsed: 2 * time.Minute, Pieces: 60, Singles: 2, Doubles: 1, Triples: 2, Tetrises: 3},
+		expectedLines:      22,
+		expectedPPS:        0.5,
+		expectedLPM:        11,
+		expectedTetrisRate: 12.0 / 22.0,
+	},
+	"only tetrises": {
+		stats:              Stats{Elapsed: 30 * time.Second, Pieces: 20, Tetrises: 2},
+		expectedLines:      8,
+		expectedPPS:        20.0 / 30.0,
+		expectedLPM:        16,
+		expectedTetrisRate: 1,
+	},
+}
+
+func TestStats(t *testing.T) {
+	for testName, test := range statsTests {
+		if lines := test.stats.Lines(); lines != test.expectedLines {
+			t.Errorf("Unexpected lines for test case '%s' [expected = %d, actual = %d]", testName, test.expectedLines, lines)
+		}
+		if pps := test.stats.PiecesPerSecond(); pps != test.expectedPPS {
+			t.Errorf("Unexpected pieces per second for test case '%s' [expected = %f, actual = %f]", testName, test.expectedPPS, pps)
+		}
+		if lpm := test.stats.LinesPerMinute(); lpm != test.expectedLPM {
+			t.Errorf("Unexpected lines per minute for test case '%s' [expected = %f, actual = %f]", testName, test.expectedLPM, lpm)
+		}
+		if rate := test.stats.TetrisRate(); rate != test.expectedTetrisRate {
+			t.Errorf("Unexpected tetris rate for test case '%s' [expected = %f, actual = %f]", testName, test.expectedTetrisRate, rate)
+		}
+	}
+}
+
+func TestStatsString(t *testing.T) {
+	stats := newStats()
+	stats.Elapsed = 65 * time.Second
+	for _, kind := range []tetrimino.Kind{tetrimino.IPiece, tetrimino.IPiece, tetrimino.TPiece} {
+		stats.recordPiece(kind)
+	}
+	stats.recordClear(4)
+
+	text := stats.String()
+	for _, expected := range []string{"Time: 1:05", "Pieces: 3", "I: 2", "T: 1", "Tetrises: 1", "Tetris rate: 100%"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Missing '%s' from stats [text = %q]", expected, text)
+		}
+	}
+}
+
+func TestGameStats(t *testing.T) {
+	g := newTestGame(4, 20, 4, testNewSet(tetrimino.PieceConstructors[0]))
+	g.Start()
+
+	// a horizontal "I" piece fills a whole row of a board 4 wide
+	for _, input := range fillInputSequence(MoveDown, 22) {
+		if err := g.handleInput(input); err != nil {
+			t.Fatalf("Unexpected error handling input: %s", err)
+		}
+	}
+	// the next piece falls, but isn't locked in place yet
+	g.Advance(10 * time.Second)
+
+	stats := g.Stats()
+	if stats.Pieces != 1 || stats.PieceCounts[tetrimino.IPiece] != 1 {
+		t.Errorf("Unexpected piece counts [pieces = %d, counts = %v]", stats.Pieces, stats.PieceCounts)
+	}
+	if stats.Singles != 1 {
+		t.Errorf("Unexpected singles [expected = 1, actual = %d]", stats.Singles)
+	}
+	if stats.Elapsed != 10*time.Second {
+		t.Errorf("Unexpected elapsed time [expected = %s, actual = %s]", 10*time.Second, stats.Elapsed)
+	}
+
+	// the copy returned shouldn't be affected by the rest of the game
+	g.stats.recordPiece(tetrimino.IPiece)
+	if stats.PieceCounts[tetrimino.IPiece] != 1 {
+		t.Errorf("Unexpected piece count after locking another piece [expected = 1, actual = %d]", stats.PieceCounts[tetrimino.IPiece])
+	}
+}
@@ -19,6 +19,7 @@ func newIPiece(boardWidth, boardHeight int) Tetrimino {
 			orientation:     &spawnOrientation,
 			prevOrientation: spawnOrientation,
 			color:           canvas.Cyan,
+			kind:            IPiece,
 		},
 	}
 
@@ -75,38 +76,38 @@ func (i *iPiece) Blocks() [][]*board.Block {
 	switch *i.orientation {
 	case clockwise:
 		return [][]*board.Block{
-			[]*board.Block{nil, nil, &board.Block{Color: i.color, Transparent: i.isGhost}, nil},
-			[]*board.Block{nil, nil, &board.Block{Color: i.color, Transparent: i.isGhost}, nil},
-			[]*board.Block{nil, nil, &board.Block{Color: i.color, Transparent: i.isGhost}, nil},
-			[]*board.Block{nil, nil, &board.Block{Color: i.color, Transparent: i.isGhost}, nil},
+			[]*board.Block{nil, nil, i.block(), nil},
+			[]*board.Block{nil, nil, i.block(), nil},
+			[]*board.Block{nil, nil, i.block(), nil},
+			[]*board.Block{nil, nil, i.block(), nil},
 		}
 	case opposite:
 		return [][]*board.Block{
 			[]*board.Block{nil, nil, nil, nil},
 			[]*board.Block{nil, nil, nil, nil},
 			[]*board.Block{
-				&board.Block{Color: i.color, Transparent: i.isGhost},
-				&board.Block{Color: i.color, Transparent: i.isGhost},
-				&board.Block{Color: i.color, Transparent: i.isGhost},
-				&board.Block{Color: i.color, Transparent: i.isGhost},
+				i.block(),
+				i.block(),
+				i.block(),
+				i.block(),
 			},
 			[]*board.Block{nil, nil, nil, nil},
 		}
 	case counterclockwise:
 		return [][]*board.Block{
-			[]*board.Block{nil, &board.Block{Color: i.color, Transparent: i.isGhost}, nil, nil},
-			[]*board.Block{nil, &board.Block{Color: i.color, Transparent: i.isGhost}, nil, nil},
-			[]*board.Block{nil, &board.Block{Color: i.color, Transparent: i.isGhost}, nil, nil},
-			[]*board.Block{nil, &board.Block{Color: i.color, Transparent: i.isGhost}, nil, nil},
+			[]*board.Block{nil, i.block(), nil, nil},
+			[]*board.Block{nil, i.block(), nil, nil},
+			[]*board.Block{nil, i.block(), nil, nil},
+			[]*board.Block{nil, i.block(), nil, nil},
 		}
 	case spawn:
 		return [][]*board.Block{
 			[]*board.Block{nil, nil, nil, nil},
 			[]*board.Block{
-				&board.Block{Color: i.color, Transparent: i.isGhost},
-				&board.Block{Color: i.color, Transparent: i.isGhost},
-				&board.Block{Color: i.color, Transparent: i.isGhost},
-				&board.Block{Color: i.color, Transparent: i.isGhost},
+				i.block(),
+				i.block(),
+				i.block(),
+				i.block(),
 			},
 			[]*board.Block{nil, nil, nil, nil},
 			[]*board.Block{nil, nil, nil, nil},
@@ -122,6 +123,7 @@ func (i *iPiece) SpawnGhost() Tetrimino {
 			orientation:     i.orientation,
 			prevOrientation: i.prevOrientation,
 			color:           i.color, // TODO: make different color to distinguish
+			kind:            i.kind,
 			box:             i.box,
 			isGhost:         true,
 		},
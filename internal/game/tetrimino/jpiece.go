@@ -17,6 +17,7 @@ func newJPiece(boardWidth, boardHeight int) Tetrimino {
 			orientation:     &spawnOrientation,
 			prevOrientation: spawnOrientation,
 			color:           canvas.Blue,
+			kind:            JPiece,
 		},
 	}
 
@@ -75,47 +76,47 @@ func (j *jPiece) Blocks() [][]*board.Block {
 		return [][]*board.Block{
 			[]*board.Block{
 				nil,
-				&board.Block{Color: j.color, Transparent: j.isGhost},
-				&board.Block{Color: j.color, Transparent: j.isGhost},
+				j.block(),
+				j.block(),
 			},
-			[]*board.Block{nil, &board.Block{Color: j.color, Transparent: j.isGhost}, nil},
-			[]*board.Block{nil, &board.Block{Color: j.color, Transparent: j.isGhost}, nil},
+			[]*board.Block{nil, j.block(), nil},
+			[]*board.Block{nil, j.block(), nil},
 		}
 	case opposite:
 		return [][]*board.Block{
 			[]*board.Block{nil, nil, nil},
 			[]*board.Block{
-				&board.Block{Color: j.color, Transparent: j.isGhost},
-				&board.Block{Color: j.color, Transparent: j.isGhost},
-				&board.Block{Color: j.color, Transparent: j.isGhost},
+				j.block(),
+				j.block(),
+				j.block(),
 			},
 			[]*board.Block{
 				nil,
 				nil,
-				&board.Block{Color: j.color, Transparent: j.isGhost},
+				j.block(),
 			},
 		}
 	case counterclockwise:
 		return [][]*board.Block{
-			[]*board.Block{nil, &board.Block{Color: j.color, Transparent: j.isGhost}, nil},
-			[]*board.Block{nil, &board.Block{Color: j.color, Transparent: j.isGhost}, nil},
+			[]*board.Block{nil, j.block(), nil},
+			[]*board.Block{nil, j.block(), nil},
 			[]*board.Block{
-				&board.Block{Color: j.color, Transparent: j.isGhost},
-				&board.Block{Color: j.color, Transparent: j.isGhost},
+				j.block(),
+				j.block(),
 				nil,
 			},
 		}
 	case spawn:
 		return [][]*board.Block{
 			[]*board.Block{
-				&board.Block{Color: j.color, Transparent: j.isGhost},
+				j.block(),
 				nil,
 				nil,
 			},
 			[]*board.Block{
-				&board.Block{Color: j.color, Transparent: j.isGhost},
-				&board.Block{Color: j.color, Transparent: j.isGhost},
-				&board.Block{Color: j.color, Transparent: j.isGhost},
+				j.block(),
+				j.block(),
+				j.block(),
 			},
 			[]*board.Block{nil, nil, nil},
 		}
@@ -129,6 +130,7 @@ func (j *jPiece) SpawnGhost() Tetrimino {
 			orientation:     j.orientation,
 			prevOrientation: j.prevOrientation,
 			color:           j.color, // TODO: make different color to distinguish
+			kind:            j.kind,
 			box:             j.box,
 			isGhost:         true,
 		},
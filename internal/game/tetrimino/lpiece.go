@@ -17,6 +17,7 @@ func newLPiece(boardWidth, boardHeight int) Tetrimino {
 			orientation:     &spawnOrientation,
 			prevOrientation: spawnOrientation,
 			color:           canvas.Orange,
+			kind:            LPiece,
 		},
 	}
 
@@ -73,24 +74,24 @@ func (l *lPiece) Blocks() [][]*board.Block {
 	switch *l.orientation {
 	case clockwise:
 		return [][]*board.Block{
-			[]*board.Block{nil, &board.Block{Color: l.color, Transparent: l.isGhost}, nil},
-			[]*board.Block{nil, &board.Block{Color: l.color, Transparent: l.isGhost}, nil},
+			[]*board.Block{nil, l.block(), nil},
+			[]*board.Block{nil, l.block(), nil},
 			[]*board.Block{
 				nil,
-				&board.Block{Color: l.color, Transparent: l.isGhost},
-				&board.Block{Color: l.color, Transparent: l.isGhost},
+				l.block(),
+				l.block(),
 			},
 		}
 	case opposite:
 		return [][]*board.Block{
 			[]*board.Block{nil, nil, nil},
 			[]*board.Block{
-				&board.Block{Color: l.color, Transparent: l.isGhost},
-				&board.Block{Color: l.color, Transparent: l.isGhost},
-				&board.Block{Color: l.color, Transparent: l.isGhost},
+				l.block(),
+				l.block(),
+				l.block(),
 			},
 			[]*board.Block{
-				&board.Block{Color: l.color, Transparent: l.isGhost},
+				l.block(),
 				nil,
 				nil,
 			},
@@ -98,24 +99,24 @@ func (l *lPiece) Blocks() [][]*board.Block {
 	case counterclockwise:
 		return [][]*board.Block{
 			[]*board.Block{
-				&board.Block{Color: l.color, Transparent: l.isGhost},
-				&board.Block{Color: l.color, Transparent: l.isGhost},
+				l.block(),
+				l.block(),
 				nil,
 			},
-			[]*board.Block{nil, &board.Block{Color: l.color, Transparent: l.isGhost}, nil},
-			[]*board.Block{nil, &board.Block{Color: l.color, Transparent: l.isGhost}, nil},
+			[]*board.Block{nil, l.block(), nil},
+			[]*board.Block{nil, l.block(), nil},
 		}
 	case spawn:
 		return [][]*board.Block{
 			[]*board.Block{
 				nil,
 				nil,
-				&board.Block{Color: l.color, Transparent: l.isGhost},
+				l.block(),
 			},
 			[]*board.Block{
-				&board.Block{Color: l.color, Transparent: l.isGhost},
-				&board.Block{Color: l.color, Transparent: l.isGhost},
-				&board.Block{Color: l.color, Transparent: l.isGhost},
+				l.block(),
+				l.block(),
+				l.block(),
 			},
 			[]*board.Block{nil, nil, nil},
 		}
@@ -129,6 +130,7 @@ func (l *lPiece) SpawnGhost() Tetrimino {
 			orientation:     l.orientation,
 			prevOrientation: l.prevOrientation,
 			color:           l.color, // TODO: make different color to distinguish
+			kind:            l.kind,
 			box:             l.box,
 			isGhost:         true,
 		},
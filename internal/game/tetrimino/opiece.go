@@ -16,6 +16,7 @@ func newOPiece(boardWidth, boardHeight int) Tetrimino {
 		tetriminoBase: &tetriminoBase{
 			orientation: &spawnOrientation,
 			color:       canvas.Yellow,
+			kind:        OPiece,
 		},
 	}
 
@@ -72,26 +73,26 @@ func (o *oPiece) Blocks() [][]*board.Block {
 	switch *o.orientation {
 	case spawn:
 		return [][]*board.Block{
-			[]*board.Block{nil, &board.Block{Color: o.color, Transparent: o.isGhost}, &board.Block{Color: o.color, Transparent: o.isGhost}, nil},
-			[]*board.Block{nil, &board.Block{Color: o.color, Transparent: o.isGhost}, &board.Block{Color: o.color, Transparent: o.isGhost}, nil},
+			[]*board.Block{nil, o.block(), o.block(), nil},
+			[]*board.Block{nil, o.block(), o.block(), nil},
 			[]*board.Block{nil, nil, nil, nil},
 		}
 	case clockwise:
 		return [][]*board.Block{
-			[]*board.Block{nil, &board.Block{Color: o.color, Transparent: o.isGhost}, &board.Block{Color: o.color, Transparent: o.isGhost}, nil},
-			[]*board.Block{nil, &board.Block{Color: o.color, Transparent: o.isGhost}, &board.Block{Color: o.color, Transparent: o.isGhost}, nil},
+			[]*board.Block{nil, o.block(), o.block(), nil},
+			[]*board.Block{nil, o.block(), o.block(), nil},
 			[]*board.Block{nil, nil, nil, nil},
 		}
 	case opposite:
 		return [][]*board.Block{
-			[]*board.Block{nil, &board.Block{Color: o.color, Transparent: o.isGhost}, &board.Block{Color: o.color, Transparent: o.isGhost}, nil},
-			[]*board.Block{nil, &board.Block{Color: o.color, Transparent: o.isGhost}, &board.Block{Color: o.color, Transparent: o.isGhost}, nil},
+			[]*board.Block{nil, o.block(), o.block(), nil},
+			[]*board.Block{nil, o.block(), o.block(), nil},
 			[]*board.Block{nil, nil, nil, nil},
 		}
 	case counterclockwise:
 		return [][]*board.Block{
-			[]*board.Block{nil, &board.Block{Color: o.color, Transparent: o.isGhost}, &board.Block{Color: o.color, Transparent: o.isGhost}, nil},
-			[]*board.Block{nil, &board.Block{Color: o.color, Transparent: o.isGhost}, &board.Block{Color: o.color, Transparent: o.isGhost}, nil},
+			[]*board.Block{nil, o.block(), o.block(), nil},
+			[]*board.Block{nil, o.block(), o.block(), nil},
 			[]*board.Block{nil, nil, nil, nil},
 		}
 	}
@@ -104,6 +105,7 @@ func (o *oPiece) SpawnGhost() Tetrimino {
 			orientation:     o.orientation,
 			prevOrientation: o.prevOrientation,
 			color:           o.color, // TODO: make different color to distinguish
+			kind:            o.kind,
 			box:             o.box,
 			isGhost:         true,
 		},
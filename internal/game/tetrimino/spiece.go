@@ -17,6 +17,7 @@ func newSPiece(boardWidth, boardHeight int) Tetrimino {
 			orientation:     &spawnOrientation,
 			prevOrientation: spawnOrientation,
 			color:           canvas.Green,
+			kind:            SPiece,
 		},
 	}
 
@@ -75,18 +76,18 @@ func (s *sPiece) Blocks() [][]*board.Block {
 		return [][]*board.Block{
 			[]*board.Block{
 				nil,
-				&board.Block{Color: s.color, Transparent: s.isGhost},
+				s.block(),
 				nil,
 			},
 			[]*board.Block{
 				nil,
-				&board.Block{Color: s.color, Transparent: s.isGhost},
-				&board.Block{Color: s.color, Transparent: s.isGhost},
+				s.block(),
+				s.block(),
 			},
 			[]*board.Block{
 				nil,
 				nil,
-				&board.Block{Color: s.color, Transparent: s.isGhost},
+				s.block(),
 			},
 		}
 	case opposite:
@@ -94,30 +95,30 @@ func (s *sPiece) Blocks() [][]*board.Block {
 			[]*board.Block{nil, nil, nil},
 			[]*board.Block{
 				nil,
-				&board.Block{Color: s.color, Transparent: s.isGhost},
-				&board.Block{Color: s.color, Transparent: s.isGhost},
+				s.block(),
+				s.block(),
 			},
 			[]*board.Block{
-				&board.Block{Color: s.color, Transparent: s.isGhost},
-				&board.Block{Color: s.color, Transparent: s.isGhost},
+				s.block(),
+				s.block(),
 				nil,
 			},
 		}
 	case counterclockwise:
 		return [][]*board.Block{
 			[]*board.Block{
-				&board.Block{Color: s.color, Transparent: s.isGhost},
+				s.block(),
 				nil,
 				nil,
 			},
 			[]*board.Block{
-				&board.Block{Color: s.color, Transparent: s.isGhost},
-				&board.Block{Color: s.color, Transparent: s.isGhost},
+				s.block(),
+				s.block(),
 				nil,
 			},
 			[]*board.Block{
 				nil,
-				&board.Block{Color: s.color, Transparent: s.isGhost},
+				s.block(),
 				nil,
 			},
 		}
@@ -125,12 +126,12 @@ func (s *sPiece) Blocks() [][]*board.Block {
 		return [][]*board.Block{
 			[]*board.Block{
 				nil,
-				&board.Block{Color: s.color, Transparent: s.isGhost},
-				&board.Block{Color: s.color, Transparent: s.isGhost},
+				s.block(),
+				s.block(),
 			},
 			[]*board.Block{
-				&board.Block{Color: s.color, Transparent: s.isGhost},
-				&board.Block{Color: s.color, Transparent: s.isGhost},
+				s.block(),
+				s.block(),
 				nil,
 			},
 			[]*board.Block{nil, nil, nil},
@@ -145,6 +146,7 @@ func (s *sPiece) SpawnGhost() Tetrimino {
 			orientation:     s.orientation,
 			prevOrientation: s.prevOrientation,
 			color:           s.color, // TODO: make different color to distinguish
+			kind:            s.kind,
 			box:             s.box,
 			isGhost:         true,
 		},
@@ -3,7 +3,7 @@ package tetrimino
 import (
 	"fmt"
 	"math/rand"
-	"time"
+	"strings"
 
 	"github.com/ShawnROGrady/gotris/internal/canvas"
 	"github.com/ShawnROGrady/gotris/internal/game/board"
@@ -37,6 +37,56 @@ type Tetrimino interface {
 	RotationTests() []RotationTest
 	SpawnGhost() Tetrimino
 	ToggleGhost()
+	Kind() Kind
+	SetColor(c canvas.Color)
+}
+
+// Kind identifies the shape of a tetrimino
+type Kind int
+
+// the available kinds of tetrimino
+const (
+	IPiece Kind = iota
+	JPiece
+	LPiece
+	OPiece
+	SPiece
+	TPiece
+	ZPiece
+)
+
+// Kinds returns every kind of tetrimino, in the same order as PieceConstructors
+func Kinds() []Kind {
+	return []Kind{IPiece, JPiece, LPiece, OPiece, SPiece, TPiece, ZPiece}
+}
+
+func (k Kind) String() string {
+	kindNames := map[Kind]string{
+		IPiece: "I",
+		JPiece: "J",
+		LPiece: "L",
+		OPiece: "O",
+		SPiece: "S",
+		TPiece: "T",
+		ZPiece: "Z",
+	}
+
+	return kindNames[k]
+}
+
+// MarshalText encodes the kind using its name, so kinds can be used as keys of JSON objects
+func (k Kind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// KindFromName returns the kind of tetrimino with the specified name (e.g. "T")
+func KindFromName(name string) (Kind, error) {
+	for _, k := range Kinds() {
+		if strings.EqualFold(k.String(), name) {
+			return k, nil
+		}
+	}
+	return 0, fmt.Errorf("unrecognized piece: '%s'", name)
 }
 
 // Coordinates represent a blocks position on the board
@@ -55,11 +105,18 @@ type PieceConstructor func(boardWidth, boardHeight int) Tetrimino
 // TODO: figure out better way to enable testing
 var PieceConstructors = []PieceConstructor{newIPiece, newJPiece, newLPiece, newOPiece, newSPiece, newTPiece, newZPiece}
 
-// NewSet generates a new set of tetriminos
-// this set is a random permutation of all piece types: https://harddrop.com/wiki/Random_Generator
-func NewSet(boardWidth, boardHeight int) []Tetrimino {
+// SeededSets returns a function generating new sets of tetriminos, using a random source with the specified seed
+// each set is a random permutation of all piece types: https://harddrop.com/wiki/Random_Generator
+// the same seed always generates the same sequence of sets
+func SeededSets(seed int64) func(boardWidth, boardHeight int) []Tetrimino {
+	r := rand.New(rand.NewSource(seed))
+	return func(boardWidth, boardHeight int) []Tetrimino {
+		return newSet(r, boardWidth, boardHeight)
+	}
+}
+
+func newSet(r *rand.Rand, boardWidth, boardHeight int) []Tetrimino {
 	var (
-		r        = rand.New(rand.NewSource(time.Now().UnixNano()))
 		perm     = r.Perm(len(PieceConstructors))
 		pieceSet = []Tetrimino{}
 	)
@@ -76,6 +133,7 @@ type tetriminoBase struct {
 	orientation     *orientation
 	prevOrientation orientation
 	color           canvas.Color
+	kind            Kind
 	isGhost         bool
 }
 
@@ -125,6 +183,19 @@ func (t *tetriminoBase) ToggleGhost() {
 	t.isGhost = !t.isGhost
 }
 
+func (t *tetriminoBase) SetColor(c canvas.Color) {
+	t.color = c
+}
+
+func (t *tetriminoBase) Kind() Kind {
+	return t.kind
+}
+
+// block constructs a single block of the piece
+func (t *tetriminoBase) block() *board.Block {
+	return &board.Block{Color: t.color, Transparent: t.isGhost, Label: t.kind.String()}
+}
+
 // Box represents the box surrounding the current piece
 // this way we don't have to track the coordinates of each block
 type Box struct {
@@ -2,7 +2,10 @@ package tetrimino
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/ShawnROGrady/gotris/internal/canvas"
 )
 
 type tetriminoTestCase struct {
@@ -368,3 +371,46 @@ func checkRotationTests(piece Tetrimino, wallKickTests map[orientation]map[orien
 	}
 	return nil
 }
+
+func TestKinds(t *testing.T) {
+	for i, constructor := range PieceConstructors {
+		var (
+			piece    = constructor(10, 24)
+			expected = Kinds()[i]
+		)
+		if piece.Kind() != expected {
+			t.Errorf("Unexpected kind for piece %d [expected = %s, actual = %s]", i, expected, piece.Kind())
+		}
+		if ghost := piece.SpawnGhost(); ghost.Kind() != expected {
+			t.Errorf("Unexpected kind for ghost of piece %d [expected = %s, actual = %s]", i, expected, ghost.Kind())
+		}
+
+		kind, err := KindFromName(strings.ToLower(expected.String()))
+		if err != nil {
+			t.Errorf("Unexpected error finding kind '%s': %s", expected, err)
+		} else if kind != expected {
+			t.Errorf("Unexpected kind from name '%s' [expected = %s, actual = %s]", expected, expected, kind)
+		}
+	}
+
+	if _, err := KindFromName("X"); err == nil {
+		t.Errorf("Unexpectedly no error finding kind 'X'")
+	}
+}
+
+func TestSetColor(t *testing.T) {
+	for i, constructor := range PieceConstructors {
+		piece := constructor(10, 24)
+		piece.SetColor(canvas.RGB(1, 2, 3))
+
+		for _, piece := range []Tetrimino{piece, piece.SpawnGhost()} {
+			for _, row := range piece.Blocks() {
+				for _, block := range row {
+					if block != nil && block.Color != canvas.RGB(1, 2, 3) {
+						t.Errorf("Unexpected block color for piece %d [expected = %v, actual = %v]", i, canvas.RGB(1, 2, 3), block.Color)
+					}
+				}
+			}
+		}
+	}
+}
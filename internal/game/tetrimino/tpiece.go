@@ -17,6 +17,7 @@ func newTPiece(boardWidth, boardHeight int) Tetrimino {
 			orientation:     &spawnOrientation,
 			prevOrientation: spawnOrientation,
 			color:           canvas.Magenta,
+			kind:            TPiece,
 		},
 	}
 
@@ -75,17 +76,17 @@ func (t *tPiece) Blocks() [][]*board.Block {
 		return [][]*board.Block{
 			[]*board.Block{
 				nil,
-				&board.Block{Color: t.color, Transparent: t.isGhost},
+				t.block(),
 				nil,
 			},
 			[]*board.Block{
 				nil,
-				&board.Block{Color: t.color, Transparent: t.isGhost},
-				&board.Block{Color: t.color, Transparent: t.isGhost},
+				t.block(),
+				t.block(),
 			},
 			[]*board.Block{
 				nil,
-				&board.Block{Color: t.color, Transparent: t.isGhost},
+				t.block(),
 				nil,
 			},
 		}
@@ -93,13 +94,13 @@ func (t *tPiece) Blocks() [][]*board.Block {
 		return [][]*board.Block{
 			[]*board.Block{nil, nil, nil},
 			[]*board.Block{
-				&board.Block{Color: t.color, Transparent: t.isGhost},
-				&board.Block{Color: t.color, Transparent: t.isGhost},
-				&board.Block{Color: t.color, Transparent: t.isGhost},
+				t.block(),
+				t.block(),
+				t.block(),
 			},
 			[]*board.Block{
 				nil,
-				&board.Block{Color: t.color, Transparent: t.isGhost},
+				t.block(),
 				nil,
 			},
 		}
@@ -107,17 +108,17 @@ func (t *tPiece) Blocks() [][]*board.Block {
 		return [][]*board.Block{
 			[]*board.Block{
 				nil,
-				&board.Block{Color: t.color, Transparent: t.isGhost},
+				t.block(),
 				nil,
 			},
 			[]*board.Block{
-				&board.Block{Color: t.color, Transparent: t.isGhost},
-				&board.Block{Color: t.color, Transparent: t.isGhost},
+				t.block(),
+				t.block(),
 				nil,
 			},
 			[]*board.Block{
 				nil,
-				&board.Block{Color: t.color, Transparent: t.isGhost},
+				t.block(),
 				nil,
 			},
 		}
@@ -125,13 +126,13 @@ func (t *tPiece) Blocks() [][]*board.Block {
 		return [][]*board.Block{
 			[]*board.Block{
 				nil,
-				&board.Block{Color: t.color, Transparent: t.isGhost},
+				t.block(),
 				nil,
 			},
 			[]*board.Block{
-				&board.Block{Color: t.color, Transparent: t.isGhost},
-				&board.Block{Color: t.color, Transparent: t.isGhost},
-				&board.Block{Color: t.color, Transparent: t.isGhost},
+				t.block(),
+				t.block(),
+				t.block(),
 			},
 			[]*board.Block{nil, nil, nil},
 		}
@@ -145,6 +146,7 @@ func (t *tPiece) SpawnGhost() Tetrimino {
 			orientation:     t.orientation,
 			prevOrientation: t.prevOrientation,
 			color:           t.color, // TODO: make different color to distinguish
+			kind:            t.kind,
 			box:             t.box,
 			isGhost:         true,
 		},
@@ -17,6 +17,7 @@ func newZPiece(boardWidth, boardHeight int) Tetrimino {
 			orientation:     &spawnOrientation,
 			prevOrientation: spawnOrientation,
 			color:           canvas.Red,
+			kind:            ZPiece,
 		},
 	}
 
@@ -76,16 +77,16 @@ func (z *zPiece) Blocks() [][]*board.Block {
 			[]*board.Block{
 				nil,
 				nil,
-				&board.Block{Color: z.color, Transparent: z.isGhost},
+				z.block(),
 			},
 			[]*board.Block{
 				nil,
-				&board.Block{Color: z.color, Transparent: z.isGhost},
-				&board.Block{Color: z.color, Transparent: z.isGhost},
+				z.block(),
+				z.block(),
 			},
 			[]*board.Block{
 				nil,
-				&board.Block{Color: z.color, Transparent: z.isGhost},
+				z.block(),
 				nil,
 			},
 		}
@@ -93,30 +94,30 @@ func (z *zPiece) Blocks() [][]*board.Block {
 		return [][]*board.Block{
 			[]*board.Block{nil, nil, nil},
 			[]*board.Block{
-				&board.Block{Color: z.color, Transparent: z.isGhost},
-				&board.Block{Color: z.color, Transparent: z.isGhost},
+				z.block(),
+				z.block(),
 				nil,
 			},
 			[]*board.Block{
 				nil,
-				&board.Block{Color: z.color, Transparent: z.isGhost},
-				&board.Block{Color: z.color, Transparent: z.isGhost},
+				z.block(),
+				z.block(),
 			},
 		}
 	case counterclockwise:
 		return [][]*board.Block{
 			[]*board.Block{
 				nil,
-				&board.Block{Color: z.color, Transparent: z.isGhost},
+				z.block(),
 				nil,
 			},
 			[]*board.Block{
-				&board.Block{Color: z.color, Transparent: z.isGhost},
-				&board.Block{Color: z.color, Transparent: z.isGhost},
+				z.block(),
+				z.block(),
 				nil,
 			},
 			[]*board.Block{
-				&board.Block{Color: z.color, Transparent: z.isGhost},
+				z.block(),
 				nil,
 				nil,
 			},
@@ -124,14 +125,14 @@ func (z *zPiece) Blocks() [][]*board.Block {
 	case spawn:
 		return [][]*board.Block{
 			[]*board.Block{
-				&board.Block{Color: z.color, Transparent: z.isGhost},
-				&board.Block{Color: z.color, Transparent: z.isGhost},
+				z.block(),
+				z.block(),
 				nil,
 			},
 			[]*board.Block{
 				nil,
-				&board.Block{Color: z.color, Transparent: z.isGhost},
-				&board.Block{Color: z.color, Transparent: z.isGhost},
+				z.block(),
+				z.block(),
 			},
 			[]*board.Block{nil, nil, nil},
 		}
@@ -145,6 +146,7 @@ func (z *zPiece) SpawnGhost() Tetrimino {
 			orientation:     z.orientation,
 			prevOrientation: z.prevOrientation,
 			color:           z.color, // TODO: make different color to distinguish
+			kind:            z.kind,
 			box:             z.box,
 			isGhost:         true,
 		},
@@ -0,0 +1,87 @@
+package game
+
+import (
+	"github.com/ShawnROGrady/gotris/internal/game/tetrimino"
+)
+
+// EndReason describes why a game ended
+type EndReason int
+
+// the possible reasons for a game ending
+// see: https://tetris.wiki/Top_out for the different ways of topping out
+const (
+	NotOver EndReason = iota
+	// BlockOut means a newly spawned piece overlapped an existing block
+	BlockOut
+	// LockOut means a piece was locked entirely above the visible field
+	LockOut
+	// PartialLockOut means a piece was locked partially above the visible field
+	PartialLockOut
+	// GoalReached means the line goal for the mode was reached
+	GoalReached
+	// TimeUp means the time limit for the mode was reached
+	TimeUp
+)
+
+func (e EndReason) String() string {
+	reasonDescriptions := map[EndReason]string{
+		NotOver:        "not over",
+		BlockOut:       "block out",
+		LockOut:        "lock out",
+		PartialLockOut: "partial lock out",
+		GoalReached:    "goal reached",
+		TimeUp:         "time up",
+	}
+
+	return reasonDescriptions[e]
+}
+
+// MarshalText encodes the reason using its description
+func (e EndReason) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// endGame renders the final state of the game once it has ended
+func (g *Game) endGame() error {
+	// still render game-over state, without waiting for the next frame
+	b := g.board
+	if g.endReason == BlockOut {
+		// show the piece which couldn't be spawned, since that's what ended the game
+		b = g.boardWithBlockOut()
+	}
+	g.canvas.UpdateCells(g.cells(b))
+	if gCanvas, ok := g.canvas.(*gCanvas); ok {
+		if err := gCanvas.flush(); err != nil {
+			return err
+		}
+	}
+	return g.canvas.Render()
+}
+
+// lockOutReason checks if the current piece was locked in a position that should end the game
+// this must be checked prior to clearing any rows, since the piece coordinates are not updated
+func (e *Engine) lockOutReason() EndReason {
+	if e.pieceAboveField() {
+		return LockOut
+	}
+	if e.partialLockOut && e.pieceAtTop() {
+		return PartialLockOut
+	}
+	return NotOver
+}
+
+// checks if the current piece is entirely in the hidden row(s)
+func (e *Engine) pieceAboveField() bool {
+	return e.currentPiece.YMin().Y > len(e.board.Blocks)-e.board.HiddenRows()-1
+}
+
+// pieceOverlaps checks if any block of the piece occupies an already filled space
+// used to detect a block out when a new piece is spawned
+func (e *Engine) pieceOverlaps(piece tetrimino.Tetrimino) bool {
+	for coords := range pieceCoords(piece, e.board.Blocks) {
+		if e.board.Blocks[coords.Y][coords.X] != nil {
+			return true
+		}
+	}
+	return false
+}
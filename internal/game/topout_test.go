@@ -0,0 +1,158 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ShawnROGrady/gotris/internal/game/board"
+	"github.com/ShawnROGrady/gotris/internal/game/tetrimino"
+)
+
+var topOutTests = map[string]struct {
+	pieceConstructor tetrimino.PieceConstructor
+	partialLockOut   bool
+	setup            func(g *Game)
+	inputSequence    []Action
+	expectedReason   EndReason
+}{
+	"hard drop i piece until lock out": {
+		pieceConstructor: tetrimino.PieceConstructors[0],
+		inputSequence:    fillInputSequence(MoveUp, 20), // 21 drops: 20 to fill the visible rows, one more to lock above them
+		expectedReason:   LockOut,
+	},
+	"hard drop t piece until lock out": {
+		pieceConstructor: tetrimino.PieceConstructors[5],
+		inputSequence:    fillInputSequence(MoveUp, 10), // 11 drops: 10 to fill the visible rows, one more to lock above them
+		expectedReason:   LockOut,
+	},
+	"i piece spawns on existing blocks": {
+		pieceConstructor: tetrimino.PieceConstructors[0],
+		setup: func(g *Game) {
+			// move the current piece out of the spawn area then fill that area
+			for i := 0; i < 4; i++ {
+				g.handleInput(MoveDown)
+			}
+			for x := 3; x < 7; x++ {
+				g.board.Blocks[22][x] = &board.Block{}
+			}
+		},
+		inputSequence:  []Action{MoveUp},
+		expectedReason: BlockOut,
+	},
+	"i piece locks partially above field": {
+		pieceConstructor: tetrimino.PieceConstructors[0],
+		// one horizontal piece, then 5 vertical pieces with the last one in rows 17-20
+		inputSequence: combineInputSequences(
+			[]Action{MoveUp},
+			[]Action{RotateRight, MoveUp},
+			[]Action{RotateRight, MoveUp},
+			[]Action{RotateRight, MoveUp},
+			[]Action{RotateRight, MoveUp},
+			[]Action{RotateRight, MoveUp},
+		),
+		expectedReason: NotOver,
+	},
+	"i piece locks partially above field, with partial lock out": {
+		pieceConstructor: tetrimino.PieceConstructors[0],
+		partialLockOut:   true,
+		inputSequence: combineInputSequences(
+			[]Action{MoveUp},
+			[]Action{RotateRight, MoveUp},
+			[]Action{RotateRight, MoveUp},
+			[]Action{RotateRight, MoveUp},
+			[]Action{RotateRight, MoveUp},
+			[]Action{RotateRight, MoveUp},
+		),
+		expectedReason: PartialLockOut,
+	},
+}
+
+func TestTopOut(t *testing.T) {
+	for testName, test := range topOutTests {
+		g := newTestGame(10, 20, 4, testNewSet(test.pieceConstructor))
+		g.partialLockOut = test.partialLockOut
+		g.addPieceToBoard(g.currentPiece)
+		g.ghostPiece = g.findGhostPiece()
+
+		if test.setup != nil {
+			test.setup(g)
+		}
+
+		for _, input := range test.inputSequence {
+			if err := g.handleInput(input); err != nil {
+				t.Fatalf("Unexpected error handling user input for test case '%s': %s", testName, err)
+			}
+			if g.EndReason() != NotOver {
+				break
+			}
+		}
+
+		if g.EndReason() != test.expectedReason {
+			t.Errorf("Unexpected end reason for test case '%s' [expected = %s, actual = %s]", testName, test.expectedReason, g.EndReason())
+		}
+		if test.expectedReason == BlockOut {
+			checkBlockOutFrame(t, testName, g)
+		}
+	}
+}
+
+// checkBlockOutFrame checks that the final frame shows the piece which couldn't be spawned, without adding it to the board
+func checkBlockOutFrame(t *testing.T, testName string, g *Game) {
+	t.Helper()
+
+	var (
+		final      = g.boardWithBlockOut()
+		topL       = g.currentPiece.ContainingBox().TopLeft
+		overlapped = 0
+	)
+	for i, row := range g.currentPiece.Blocks() {
+		for j, block := range row {
+			if block == nil {
+				continue
+			}
+			x, y := topL.X+j, topL.Y-i
+			if !reflect.DeepEqual(final.Blocks[y][x], block) {
+				t.Errorf("Missing block of the spawned piece at (%d, %d) in the final frame for test case '%s'", x, y, testName)
+			}
+			if reflect.DeepEqual(g.board.Blocks[y][x], block) {
+				t.Errorf("Unexpected block of the spawned piece at (%d, %d) on the board for test case '%s'", x, y, testName)
+			}
+			if g.board.Blocks[y][x] != nil {
+				overlapped++
+			}
+		}
+	}
+	if overlapped == 0 {
+		t.Errorf("Unexpectedly no blocks overlapped by the spawned piece for test case '%s'", testName)
+	}
+
+	canvas := g.canvas.(*testCanvas)
+	if expected := g.cells(final); !reflect.DeepEqual(canvas.cells, expected) {
+		t.Errorf("Unexpected final frame for test case '%s' [expected = %v, actual = %v]", testName, expected, canvas.cells)
+	}
+}
+
+func TestBlockOutFrameVisible(t *testing.T) {
+	// without any hidden rows the piece spawns in the visible field, so the final frame shows it over the blocks it overlaps
+	g := newTestGame(10, 20, 0, testNewSet(tetrimino.PieceConstructors[0]))
+	g.addPieceToBoard(g.currentPiece)
+	g.ghostPiece = g.findGhostPiece()
+
+	for i := 0; i < 4; i++ {
+		g.handleInput(MoveDown)
+	}
+	for x := 3; x < 7; x++ {
+		g.board.Blocks[18][x] = &board.Block{}
+	}
+	if err := g.handleInput(MoveUp); err != nil {
+		t.Fatalf("Unexpected error handling user input: %s", err)
+	}
+	if g.EndReason() != BlockOut {
+		t.Fatalf("Unexpected end reason [expected = %s, actual = %s]", BlockOut, g.EndReason())
+	}
+
+	checkBlockOutFrame(t, "visible spawn", g)
+	if reflect.DeepEqual(g.canvas.(*testCanvas).cells, g.cells(g.board)) {
+		t.Errorf("Unexpected final frame, which doesn't show the spawned piece")
+	}
+}
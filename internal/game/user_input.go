@@ -1,42 +1,45 @@
 package game
 
 import (
-	"github.com/ShawnROGrady/gotris/internal/inputreader"
+	"context"
 )
 
-type userInput int
+// Action is something the player can do to the current piece
+type Action int
 
+// the available actions
 const (
-	moveLeft userInput = iota
-	moveRight
-	moveDown
-	moveUp
-	rotateLeft
-	rotateRight
+	MoveLeft Action = iota
+	MoveRight
+	MoveDown
+	// MoveUp hard drops the current piece, or moves it up in debug mode
+	MoveUp
+	RotateLeft
+	RotateRight
 	ignore
 )
 
-func (u userInput) String() string {
-	inputDescriptions := map[userInput]string{
-		moveLeft:    "move left",
-		moveDown:    "move down",
-		moveUp:      "move up",
-		moveRight:   "move right",
-		rotateLeft:  "rotate left",
-		rotateRight: "rotate right",
+func (u Action) String() string {
+	inputDescriptions := map[Action]string{
+		MoveLeft:    "move left",
+		MoveDown:    "move down",
+		MoveUp:      "move up",
+		MoveRight:   "move right",
+		RotateLeft:  "rotate left",
+		RotateRight: "rotate right",
 	}
 
 	return inputDescriptions[u]
 }
 
-func (u userInput) opposite() userInput {
-	var oppositeInput = map[userInput]userInput{
-		moveLeft:    moveRight,
-		moveDown:    moveUp,
-		moveUp:      moveDown,
-		moveRight:   moveLeft,
-		rotateLeft:  rotateRight,
-		rotateRight: rotateLeft,
+func (u Action) opposite() Action {
+	var oppositeInput = map[Action]Action{
+		MoveLeft:    MoveRight,
+		MoveDown:    MoveUp,
+		MoveUp:      MoveDown,
+		MoveRight:   MoveLeft,
+		RotateLeft:  RotateRight,
+		RotateRight: RotateLeft,
 	}
 
 	if opposite, ok := oppositeInput[u]; ok {
@@ -46,23 +49,29 @@ func (u userInput) opposite() userInput {
 	return ignore
 }
 
-func translateInput(done <-chan bool, inputreader inputreader.InputReader, controlMap map[string]userInput) (<-chan userInput, <-chan error) {
-	rawInput, readErr := inputreader.ReadInput(done)
-
-	translatedInput := make(chan userInput)
-
-	go func() {
-		for {
+// translateInput sends the user input each raw input is mapped to by the control map, ignoring any others, until the context is cancelled
+// a raw input received once the context is cancelled is passed to unread, so it can be handled by whatever reads the input next
+func translateInput(ctx context.Context, rawInput <-chan []byte, controlMap map[string]Action, translated chan<- Action, unread func([]byte)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case input := <-rawInput:
+			if ctx.Err() != nil {
+				// the context was cancelled as the input was received
+				unread(input)
+				return
+			}
+			in, ok := controlMap[string(input)]
+			if !ok {
+				continue
+			}
 			select {
-			case <-done:
+			case <-ctx.Done():
+				unread(input)
 				return
-			case input := <-rawInput:
-				if translated, ok := controlMap[string(input)]; ok {
-					translatedInput <- translated
-				}
+			case translated <- in:
 			}
 		}
-	}()
-
-	return translatedInput, readErr
+	}
 }
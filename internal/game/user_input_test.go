@@ -1,6 +1,7 @@
 package game
 
 import (
+	"context"
 	"io"
 	"testing"
 	"time"
@@ -11,7 +12,7 @@ import (
 var translateInputTests = map[string]struct {
 	scheme              ControlScheme
 	inputs              []string
-	expectedTranslation []userInput
+	expectedTranslation []Action
 }{
 	"Home Row": {
 		scheme: HomeRow(),
@@ -25,15 +26,15 @@ var translateInputTests = map[string]struct {
 			"j",
 			"h",
 		},
-		expectedTranslation: []userInput{
-			moveLeft,
-			moveDown,
-			moveUp,
-			moveRight,
-			moveRight,
-			moveUp,
-			moveDown,
-			moveLeft,
+		expectedTranslation: []Action{
+			MoveLeft,
+			MoveDown,
+			MoveUp,
+			MoveRight,
+			MoveRight,
+			MoveUp,
+			MoveDown,
+			MoveLeft,
 		},
 	},
 	"Arrow Keys": {
@@ -48,15 +49,15 @@ var translateInputTests = map[string]struct {
 			downArrow().String(),
 			leftArrow().String(),
 		},
-		expectedTranslation: []userInput{
-			moveLeft,
-			moveDown,
-			moveUp,
-			moveRight,
-			moveRight,
-			moveUp,
-			moveDown,
-			moveLeft,
+		expectedTranslation: []Action{
+			MoveLeft,
+			MoveDown,
+			MoveUp,
+			MoveRight,
+			MoveRight,
+			MoveUp,
+			MoveDown,
+			MoveLeft,
 		},
 	},
 	"Standard": {
@@ -71,15 +72,15 @@ var translateInputTests = map[string]struct {
 			downArrow().String(),
 			leftArrow().String(),
 		},
-		expectedTranslation: []userInput{
-			moveLeft,
-			moveDown,
-			moveUp,
-			moveRight,
-			moveRight,
-			moveUp,
-			moveDown,
-			moveLeft,
+		expectedTranslation: []Action{
+			MoveLeft,
+			MoveDown,
+			MoveUp,
+			MoveRight,
+			MoveRight,
+			MoveUp,
+			MoveDown,
+			MoveLeft,
 		},
 	},
 	"Home Row+Arrow Keys": {
@@ -94,15 +95,15 @@ var translateInputTests = map[string]struct {
 			downArrow().String(),
 			leftArrow().String(),
 		},
-		expectedTranslation: []userInput{
-			moveLeft,
-			moveDown,
-			moveUp,
-			moveRight,
-			moveRight,
-			moveUp,
-			moveDown,
-			moveLeft,
+		expectedTranslation: []Action{
+			MoveLeft,
+			MoveDown,
+			MoveUp,
+			MoveRight,
+			MoveRight,
+			MoveUp,
+			MoveDown,
+			MoveLeft,
 		},
 	},
 }
@@ -110,21 +111,23 @@ var translateInputTests = map[string]struct {
 func TestTranslateInput(t *testing.T) {
 	for testName, test := range translateInputTests {
 		var (
-			done     = make(chan bool)
-			writeErr = make(chan error)
-			recieved = []userInput{}
+			ctx, cancel = context.WithCancel(context.Background())
+			writeErr    = make(chan error)
+			recieved    = []Action{}
+			inputs      = make(chan Action)
 		)
 
 		tReader, tWriter := io.Pipe()
 
 		reader := inputreader.NewTermReader(tReader)
 
-		inputs, readErr := translateInput(done, reader, test.scheme.controlMap())
+		rawInput, readErr := reader.ReadInput(ctx)
+		go translateInput(ctx, rawInput, test.scheme.controlMap(), inputs, reader.Unread)
 		go func() {
 			defer func() {
 				tWriter.Close()
-				time.Sleep(10 * time.Millisecond) // give ReadInput go routine enough time to read last input following 'done'
-				close(done)
+				time.Sleep(10 * time.Millisecond) // give ReadInput go routine enough time to read last input before cancelling
+				cancel()
 			}()
 			for _, in := range test.inputs {
 				_, err := tWriter.Write([]byte(in))
@@ -149,7 +152,7 @@ func TestTranslateInput(t *testing.T) {
 				}
 			case <-time.After(500 * time.Millisecond):
 				t.Fatalf("Timeout for test case '%s'", testName)
-			case <-done:
+			case <-ctx.Done():
 				if len(test.expectedTranslation) != len(recieved) {
 					t.Fatalf("Unexpected number of translated inputs received for test case '%s' [expected = %d, actual = %d]", testName, len(test.expectedTranslation), len(recieved))
 				}
@@ -163,3 +166,30 @@ func TestTranslateInput(t *testing.T) {
 		}
 	}
 }
+
+func TestTranslateInputCancelled(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		rawInput    = make(chan []byte)
+		unread      = make(chan []byte, 1)
+		finished    = make(chan struct{})
+	)
+
+	// nothing receives the translated input, e.g. since the game ended
+	go func() {
+		defer close(finished)
+		translateInput(ctx, rawInput, HomeRow().controlMap(), make(chan Action), func(input []byte) { unread <- input })
+	}()
+	rawInput <- []byte("h")
+	cancel()
+
+	select {
+	case input := <-unread:
+		if string(input) != "h" {
+			t.Errorf("Unexpected input returned to the reader [expected = h, actual = %s]", string(input))
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatalf("Timed out waiting for the input to be returned to the reader")
+	}
+	<-finished
+}
@@ -0,0 +1,183 @@
+package highscore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Defaults for the high score table
+const (
+	DefaultSize = 10
+	fileName    = "scores.json"
+)
+
+// Entry represents a single result in the high score table
+type Entry struct {
+	Initials string        `json:"initials"`
+	Score    int           `json:"score"`
+	Time     time.Duration `json:"time,omitempty"` // how long the game took, only set for tables ranked by time
+	Date     time.Time     `json:"date"`
+}
+
+// Ranking determines how the entries of a table are ordered
+type Ranking int
+
+// the available rankings
+const (
+	// ByScore ranks the highest scores first
+	ByScore Ranking = iota
+	// ByTime ranks the fastest times first, for modes which end once a goal is reached
+	ByTime
+)
+
+// beats checks if entry a is ranked above entry b
+func (r Ranking) beats(a, b Entry) bool {
+	if r == ByTime {
+		return a.Time < b.Time
+	}
+	return a.Score > b.Score
+}
+
+// ranked checks if the entry has a result which can be ranked
+func (r Ranking) ranked(e Entry) bool {
+	if r == ByTime {
+		return e.Time > 0
+	}
+	return e.Score > 0
+}
+
+// Table holds the top results for each mode and difficulty
+type Table struct {
+	size    int
+	Entries map[string][]Entry `json:"entries"`
+}
+
+// New returns an empty table which keeps the top size results for each key
+func New(size int) *Table {
+	return &Table{
+		size:    size,
+		Entries: make(map[string][]Entry),
+	}
+}
+
+// Key returns the key used to group results of the specified mode and difficulty
+func Key(mode, difficulty string) string {
+	return fmt.Sprintf("%s/%s", mode, difficulty)
+}
+
+// DefaultPath returns the location of the high score file
+// this is $XDG_DATA_HOME/gotris/scores.json, falling back to ~/.local/share/gotris/scores.json
+func DefaultPath() (string, error) {
+	return defaultPath(os.Getenv, os.UserHomeDir)
+}
+
+func defaultPath(getenv func(string) string, homeDir func() (string, error)) (string, error) {
+	dataHome := getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := homeDir()
+		if err != nil {
+			return "", err
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, "gotris", fileName), nil
+}
+
+// Load reads the table from the specified file
+// an empty table is returned if the file doesn't exist yet
+func Load(path string, size int) (*Table, error) {
+	t := New(size)
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return t, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(contents, t); err != nil {
+		return nil, fmt.Errorf("error parsing high scores from '%s': %s", path, err)
+	}
+	if t.Entries == nil {
+		t.Entries = make(map[string][]Entry)
+	}
+	return t, nil
+}
+
+// Save writes the table to the specified file, creating any missing directories
+func (t *Table) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	contents, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, contents, 0644)
+}
+
+// Qualifies checks if the entry would be added to the table for the specified key
+func (t *Table) Qualifies(key string, ranking Ranking, entry Entry) bool {
+	if !ranking.ranked(entry) {
+		return false
+	}
+	entries := t.Entries[key]
+	if len(entries) < t.size {
+		return true
+	}
+	return ranking.beats(entry, entries[len(entries)-1])
+}
+
+// Add inserts the entry into the table for the specified key, returning its rank (starting at 1)
+// an error is returned if the entry doesn't qualify
+func (t *Table) Add(key string, ranking Ranking, entry Entry) (int, error) {
+	if !t.Qualifies(key, ranking, entry) {
+		return 0, errors.New("entry does not qualify for the high score table")
+	}
+
+	entries := t.Entries[key]
+	// ties are ranked below existing entries
+	rank := sort.Search(len(entries), func(i int) bool {
+		return ranking.beats(entry, entries[i])
+	})
+
+	entries = append(entries, Entry{})
+	copy(entries[rank+1:], entries[rank:])
+	entries[rank] = entry
+
+	if len(entries) > t.size {
+		entries = entries[:t.size]
+	}
+	t.Entries[key] = entries
+
+	return rank + 1, nil
+}
+
+// Best returns the top entry for the specified key
+func (t *Table) Best(key string) (Entry, bool) {
+	entries := t.Entries[key]
+	if len(entries) == 0 {
+		return Entry{}, false
+	}
+	return entries[0], true
+}
+
+// Keys returns all keys with at least one entry in sorted order
+func (t *Table) Keys() []string {
+	keys := []string{}
+	for key, entries := range t.Entries {
+		if len(entries) != 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,227 @@
+package highscore
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// the scores are times in seconds for tables ranked by time
+var addTests = map[string]struct {
+	size           int
+	ranking        Ranking
+	existingScores []int
+	score          int
+	expectedErr    bool
+	expectedRank   int
+	expectedScores []int
+}{
+	"empty table": {
+		size:           3,
+		score:          100,
+		expectedRank:   1,
+		expectedScores: []int{100},
+	},
+	"new best": {
+		size:           3,
+		existingScores: []int{300, 200},
+		score:          400,
+		expectedRank:   1,
+		expectedScores: []int{400, 300, 200},
+	},
+	"middle of full table": {
+		size:           3,
+		existingScores: []int{300, 200, 100},
+		score:          250,
+		expectedRank:   2,
+		expectedScores: []int{300, 250, 200},
+	},
+	"tie ranked below existing": {
+		size:           3,
+		existingScores: []int{300, 200},
+		score:          200,
+		expectedRank:   3,
+		expectedScores: []int{300, 200, 200},
+	},
+	"doesn't qualify for full table": {
+		size:           3,
+		existingScores: []int{300, 200, 100},
+		score:          100,
+		expectedErr:    true,
+		expectedScores: []int{300, 200, 100},
+	},
+	"zero score": {
+		size:           3,
+		score:          0,
+		expectedErr:    true,
+		expectedScores: []int{},
+	},
+	"new fastest time": {
+		size:           3,
+		ranking:        ByTime,
+		existingScores: []int{60, 90},
+		score:          45,
+		expectedRank:   1,
+		expectedScores: []int{45, 60, 90},
+	},
+	"time tie ranked below existing": {
+		size:           3,
+		ranking:        ByTime,
+		existingScores: []int{60, 90},
+		score:          60,
+		expectedRank:   2,
+		expectedScores: []int{60, 60, 90},
+	},
+	"time too slow for full table": {
+		size:           3,
+		ranking:        ByTime,
+		existingScores: []int{60, 90, 120},
+		score:          150,
+		expectedErr:    true,
+		expectedScores: []int{60, 90, 120},
+	},
+	"no time": {
+		size:           3,
+		ranking:        ByTime,
+		score:          0,
+		expectedErr:    true,
+		expectedScores: []int{},
+	},
+}
+
+func testEntry(ranking Ranking, score int) Entry {
+	if ranking == ByTime {
+		return Entry{Initials: "ABC", Score: 100, Time: time.Duration(score) * time.Second}
+	}
+	return Entry{Initials: "ABC", Score: score}
+}
+
+func entryScore(ranking Ranking, entry Entry) int {
+	if ranking == ByTime {
+		return int(entry.Time / time.Second)
+	}
+	return entry.Score
+}
+
+func TestAdd(t *testing.T) {
+	key := Key("marathon", "beginner")
+	for testName, test := range addTests {
+		table := New(test.size)
+		for _, score := range test.existingScores {
+			table.Entries[key] = append(table.Entries[key], testEntry(test.ranking, score))
+		}
+
+		rank, err := table.Add(key, test.ranking, testEntry(test.ranking, test.score))
+		if err != nil {
+			if !test.expectedErr {
+				t.Errorf("Unexpected error for test case '%s': %s", testName, err)
+			}
+		} else if test.expectedErr {
+			t.Errorf("Unexpectedly no error for test case '%s'", testName)
+		}
+
+		if rank != test.expectedRank {
+			t.Errorf("Unexpected rank for test case '%s' [expected = %d, actual = %d]", testName, test.expectedRank, rank)
+		}
+
+		entries := table.Entries[key]
+		if len(entries) != len(test.expectedScores) {
+			t.Fatalf("Unexpected number of entries for test case '%s' [expected = %d, actual = %d]", testName, len(test.expectedScores), len(entries))
+		}
+		for i := range entries {
+			if score := entryScore(test.ranking, entries[i]); score != test.expectedScores[i] {
+				t.Errorf("Unexpected score at position %d for test case '%s' [expected = %d, actual = %d]", i, testName, test.expectedScores[i], score)
+			}
+		}
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotris")
+	if err != nil {
+		t.Fatalf("Unexpected error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "gotris", fileName)
+
+	// loading a missing file should return an empty table
+	table, err := Load(path, DefaultSize)
+	if err != nil {
+		t.Fatalf("Unexpected error loading missing file: %s", err)
+	}
+	if len(table.Keys()) != 0 {
+		t.Errorf("Unexpected keys for missing file: %v", table.Keys())
+	}
+
+	var (
+		key   = Key("sprint", "pro")
+		entry = Entry{Initials: "XYZ", Score: 1200, Date: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)}
+	)
+	if _, err := table.Add(key, ByScore, entry); err != nil {
+		t.Fatalf("Unexpected error adding entry: %s", err)
+	}
+	if err := table.Save(path); err != nil {
+		t.Fatalf("Unexpected error saving table: %s", err)
+	}
+
+	loaded, err := Load(path, DefaultSize)
+	if err != nil {
+		t.Fatalf("Unexpected error loading saved table: %s", err)
+	}
+	best, ok := loaded.Best(key)
+	if !ok {
+		t.Fatalf("Unexpectedly no best entry after loading saved table")
+	}
+	if best.Initials != entry.Initials || best.Score != entry.Score || !best.Date.Equal(entry.Date) {
+		t.Errorf("Unexpected best entry after loading saved table [expected = %+v, actual = %+v]", entry, best)
+	}
+}
+
+var defaultPathTests = map[string]struct {
+	env          map[string]string
+	homeDir      string
+	homeDirErr   error
+	expectedPath string
+	expectedErr  bool
+}{
+	"XDG_DATA_HOME set": {
+		env:          map[string]string{"XDG_DATA_HOME": "/data"},
+		homeDir:      "/home/user",
+		expectedPath: "/data/gotris/scores.json",
+	},
+	"XDG_DATA_HOME not set": {
+		homeDir:      "/home/user",
+		expectedPath: "/home/user/.local/share/gotris/scores.json",
+	},
+	"no home directory": {
+		homeDirErr:  errors.New("no home"),
+		expectedErr: true,
+	},
+}
+
+func TestDefaultPath(t *testing.T) {
+	for testName, test := range defaultPathTests {
+		var (
+			getenv  = func(key string) string { return test.env[key] }
+			homeDir = func() (string, error) { return test.homeDir, test.homeDirErr }
+		)
+
+		path, err := defaultPath(getenv, homeDir)
+		if err != nil {
+			if !test.expectedErr {
+				t.Errorf("Unexpected error for test case '%s': %s", testName, err)
+			}
+			continue
+		}
+		if test.expectedErr {
+			t.Errorf("Unexpectedly no error for test case '%s'", testName)
+		}
+		if path != test.expectedPath {
+			t.Errorf("Unexpected path for test case '%s' [expected = %s, actual = %s]", testName, test.expectedPath, path)
+		}
+	}
+}
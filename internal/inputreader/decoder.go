@@ -0,0 +1,115 @@
+package inputreader
+
+import (
+	"unicode/utf8"
+)
+
+const esc = 0x1b
+
+// keyDecoder splits raw terminal input into discrete keys
+// a key may be a single character (including multi-byte UTF-8 characters), a control character,
+// a CSI (ESC [ ...) or SS3 (ESC O x) escape sequence, or an alt-prefixed key (ESC x)
+// input which may be the start of a longer key is held until more input arrives or it is flushed
+type keyDecoder struct {
+	pending []byte
+}
+
+// decode appends the input to any pending input then returns all complete keys
+func (d *keyDecoder) decode(input []byte) [][]byte {
+	d.pending = append(d.pending, input...)
+
+	keys := [][]byte{}
+	for len(d.pending) != 0 {
+		n, complete := keyLength(d.pending)
+		if !complete {
+			break
+		}
+		keys = append(keys, d.take(n))
+	}
+	return keys
+}
+
+// flush returns any pending input as a single key, treating an incomplete sequence as complete
+// this is used when no more input arrives in time, e.g. so that a lone ESC is reported
+func (d *keyDecoder) flush() [][]byte {
+	if len(d.pending) == 0 {
+		return [][]byte{}
+	}
+	return [][]byte{d.take(len(d.pending))}
+}
+
+// hasPending reports whether there is input waiting on more input
+func (d *keyDecoder) hasPending() bool {
+	return len(d.pending) != 0
+}
+
+func (d *keyDecoder) take(n int) []byte {
+	key := make([]byte, n)
+	copy(key, d.pending[:n])
+	d.pending = d.pending[n:]
+	return key
+}
+
+// keyLength determines the number of bytes making up the first key of the input
+// complete is false if more input is needed to determine the key
+func keyLength(input []byte) (n int, complete bool) {
+	switch b := input[0]; {
+	case b == esc:
+		return escapeLength(input)
+	case b < utf8.RuneSelf:
+		// ASCII, including control characters
+		return 1, true
+	}
+
+	if !utf8.FullRune(input) {
+		return 0, false
+	}
+	_, size := utf8.DecodeRune(input)
+	return size, true
+}
+
+// escapeLength determines the length of a key starting with ESC
+func escapeLength(input []byte) (n int, complete bool) {
+	if len(input) == 1 {
+		return 0, false
+	}
+
+	switch input[1] {
+	case '[':
+		return csiLength(input)
+	case 'O':
+		// SS3 sequences have a single final byte
+		if len(input) < 3 {
+			return 0, false
+		}
+		return 3, true
+	case esc:
+		// a lone ESC followed by another key
+		return 1, true
+	}
+
+	// alt-prefixed key
+	n, complete = keyLength(input[1:])
+	if !complete {
+		return 0, false
+	}
+	return n + 1, true
+}
+
+// csiLength determines the length of a control sequence (ESC [ parameters intermediates final)
+// see: https://en.wikipedia.org/wiki/ANSI_escape_code#CSI_(Control_Sequence_Introducer)_sequences
+func csiLength(input []byte) (n int, complete bool) {
+	for i := 2; i < len(input); i++ {
+		switch b := input[i]; {
+		case b >= 0x20 && b <= 0x3f:
+			// parameter and intermediate bytes
+			continue
+		case b >= 0x40 && b <= 0x7e:
+			return i + 1, true
+		default:
+			// malformed sequence, end it before the unexpected byte
+			return i, true
+		}
+	}
+	return 0, false
+}
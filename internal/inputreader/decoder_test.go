@@ -0,0 +1,101 @@
+package inputreader
+
+import (
+	"reflect"
+	"testing"
+)
+
+var decoderTests = map[string]struct {
+	reads           []string
+	expectedKeys    []string
+	expectedPending bool
+}{
+	"single keys": {
+		reads:        []string{"h", "j", "k"},
+		expectedKeys: []string{"h", "j", "k"},
+	},
+	"multiple keys in one read": {
+		reads:        []string{"hjkl"},
+		expectedKeys: []string{"h", "j", "k", "l"},
+	},
+	"arrow keys in one read": {
+		reads:        []string{"\u001b[A\u001b[Bh"},
+		expectedKeys: []string{"\u001b[A", "\u001b[B", "h"},
+	},
+	"arrow key split across reads": {
+		reads:        []string{"\u001b", "[", "D"},
+		expectedKeys: []string{"\u001b[D"},
+	},
+	"csi sequence with parameters": {
+		reads:        []string{"\u001b[1;5C\u001b[3~"},
+		expectedKeys: []string{"\u001b[1;5C", "\u001b[3~"},
+	},
+	"ss3 sequence": {
+		reads:        []string{"\u001bOA\u001bOP"},
+		expectedKeys: []string{"\u001bOA", "\u001bOP"},
+	},
+	"utf-8 character": {
+		reads:        []string{"é→"},
+		expectedKeys: []string{"é", "→"},
+	},
+	"utf-8 character split across reads": {
+		reads:        []string{"\xe2\x86", "\x92"},
+		expectedKeys: []string{"→"},
+	},
+	"control characters": {
+		reads:        []string{"\u0018\r\n\u007f"},
+		expectedKeys: []string{"\u0018", "\r", "\n", "\u007f"},
+	},
+	"alt-prefixed key": {
+		reads:        []string{"\u001bx\u001bé"},
+		expectedKeys: []string{"\u001bx", "\u001bé"},
+	},
+	"escape followed by escape sequence": {
+		reads:        []string{"\u001b\u001b[A"},
+		expectedKeys: []string{"\u001b", "\u001b[A"},
+	},
+	"malformed csi sequence": {
+		reads:        []string{"\u001b[1\u0018"},
+		expectedKeys: []string{"\u001b[1", "\u0018"},
+	},
+	"lone escape": {
+		reads:           []string{"h\u001b"},
+		expectedKeys:    []string{"h"},
+		expectedPending: true,
+	},
+	"incomplete csi sequence": {
+		reads:           []string{"\u001b[1;"},
+		expectedKeys:    []string{},
+		expectedPending: true,
+	},
+}
+
+func TestDecoder(t *testing.T) {
+	for testName, test := range decoderTests {
+		var (
+			d    = &keyDecoder{}
+			keys = []string{}
+		)
+		for _, read := range test.reads {
+			for _, key := range d.decode([]byte(read)) {
+				keys = append(keys, string(key))
+			}
+		}
+
+		if !reflect.DeepEqual(keys, test.expectedKeys) {
+			t.Errorf("Unexpected keys for test case '%s' [expected = %q, actual = %q]", testName, test.expectedKeys, keys)
+		}
+		if d.hasPending() != test.expectedPending {
+			t.Errorf("Unexpected pending state for test case '%s' [expected = %v, actual = %v]", testName, test.expectedPending, d.hasPending())
+		}
+
+		// flushing should always leave the decoder empty
+		flushed := d.flush()
+		if test.expectedPending && len(flushed) != 1 {
+			t.Errorf("Unexpected number of flushed keys for test case '%s' [expected = 1, actual = %d]", testName, len(flushed))
+		}
+		if d.hasPending() {
+			t.Errorf("Unexpected pending input after flush for test case '%s'", testName)
+		}
+	}
+}
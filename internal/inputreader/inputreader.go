@@ -1,51 +1,206 @@
 package inputreader
 
 import (
+	"context"
+	"errors"
 	"io"
+	"sync"
+	"time"
 )
 
+// ErrClosed is returned to any consumer reading after the reader was closed
+var ErrClosed = errors.New("input reader closed")
+
+// EscapeTimeout is how long to wait for the rest of an escape sequence before reporting a lone ESC
+const EscapeTimeout = 25 * time.Millisecond
+
 // InputReader represents a way to read user input
 type InputReader interface {
-	ReadInput(done <-chan bool) (<-chan []byte, <-chan error)
+	ReadInput(ctx context.Context) (<-chan []byte, <-chan error)
+}
+
+// Unreader is implemented by input readers which can take back a key that was received but never used,
+// e.g. because the consumer's context was cancelled before the key could be handled
+type Unreader interface {
+	Unread(key []byte)
 }
 
 // TermReader reads user input from the supplied terminal
+// a single TermReader may be shared by multiple consumers (e.g. consecutive games)
+// since reads from the terminal are only ever performed by one goroutine
+// the input is split into discrete keys, so each value received is a single key (e.g. "a" or "\u001b[A")
 type TermReader struct {
-	term io.Reader
+	term          io.Reader
+	escapeTimeout time.Duration
+	once          *sync.Once
+	consumers     chan consumer
+	unread        chan []byte
+	closeOnce     *sync.Once
+	closed        chan struct{}
+	wg            *sync.WaitGroup
+}
+
+// consumer represents a single call to ReadInput
+type consumer struct {
+	done    <-chan struct{}
+	input   chan []byte
+	readErr chan error
 }
 
 // NewTermReader returns a new terminal reader
 func NewTermReader(term io.Reader) *TermReader {
 	return &TermReader{
-		term: term,
+		term:          term,
+		escapeTimeout: EscapeTimeout,
+		once:          &sync.Once{},
+		consumers:     make(chan consumer),
+		unread:        make(chan []byte),
+		closeOnce:     &sync.Once{},
+		closed:        make(chan struct{}),
+		wg:            &sync.WaitGroup{},
+	}
+}
+
+// Close stops reading from the terminal, waiting for the reader's goroutines to exit
+// if the terminal is an io.Closer it's closed to interrupt any pending read,
+// otherwise the goroutine reading from it exits once the pending read returns
+func (t *TermReader) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		// prevent reading from starting, or wait for it to be started
+		t.once.Do(func() {})
+		close(t.closed)
+		if closer, ok := t.term.(io.Closer); ok {
+			err = closer.Close()
+		}
+		t.wg.Wait()
+	})
+	return err
+}
+
+// ReadInput reads the user input from the supplied terminal until the context is cancelled
+// if called again before the context is cancelled, the input is sent to the most recent consumer
+// nothing is sent on the returned channels once the context is cancelled, so they don't need to be drained
+func (t *TermReader) ReadInput(ctx context.Context) (<-chan []byte, <-chan error) {
+	c := consumer{
+		done:    ctx.Done(),
+		input:   make(chan []byte),
+		readErr: make(chan error),
+	}
+
+	t.once.Do(func() {
+		t.wg.Add(1)
+		go func() {
+			defer t.wg.Done()
+			t.read()
+		}()
+	})
+
+	select {
+	case t.consumers <- c:
+	case <-t.closed:
+		c.readErr = make(chan error, 1)
+		c.readErr <- ErrClosed
+	}
+	return c.input, c.readErr
+}
+
+// Unread returns a key received from ReadInput to the front of the queue, so it's sent to the next consumer first
+// this allows a consumer whose context is cancelled while it's handling a key to pass it on rather than dropping it
+func (t *TermReader) Unread(key []byte) {
+	select {
+	case t.unread <- key:
+	case <-t.closed:
 	}
 }
 
-// ReadInput reads the user input from the supplied terminal
-func (t *TermReader) ReadInput(done <-chan bool) (<-chan []byte, <-chan error) {
+type rawRead struct {
+	input []byte
+	err   error
+}
+
+// read continuously reads from the terminal, handing off each key to whichever consumer is currently reading
+// keys read while there is no consumer, or returned using Unread, are queued for the next one
+func (t *TermReader) read() {
 	var (
-		input   = make(chan []byte)
-		readErr = make(chan error)
+		raw      = make(chan rawRead)
+		rawDone  = make(chan struct{})
+		decoder  = &keyDecoder{}
+		queue    = [][]byte{}
+		current  *consumer
+		timeout  <-chan time.Time
+		finalErr error
 	)
 
 	go func() {
+		defer close(rawDone)
 		for {
+			buf := make([]byte, 128)
+			n, err := t.term.Read(buf)
 			select {
-			case <-done:
+			case raw <- rawRead{input: buf[:n], err: err}:
+			case <-t.closed:
+				return
+			}
+			if err != nil {
 				return
-			default:
-				buf := make([]byte, 128)
-				n, err := t.term.Read(buf)
-				if err != nil && err != io.EOF {
-					readErr <- err
-					return
-				}
-				if len(buf) != 0 {
-					input <- buf[:n]
-				}
 			}
 		}
 	}()
 
-	return input, readErr
+	for {
+		// nil channels disable the corresponding cases
+		var (
+			done    <-chan struct{}
+			input   chan []byte
+			next    []byte
+			readErr chan error
+		)
+		if current != nil {
+			done = current.done
+			if len(queue) != 0 {
+				input, next = current.input, queue[0]
+			} else if finalErr != nil {
+				readErr = current.readErr
+			}
+		}
+
+		select {
+		case <-t.closed:
+			if _, ok := t.term.(io.Closer); ok || raw == nil {
+				// the pending read was interrupted, or the reading goroutine has already exited
+				<-rawDone
+			}
+			return
+		case c := <-t.consumers:
+			current = &c
+		case key := <-t.unread:
+			queue = append([][]byte{key}, queue...)
+		case <-done:
+			current = nil
+		case input <- next:
+			queue = queue[1:]
+		case readErr <- finalErr:
+			current = nil
+		case <-timeout:
+			timeout = nil
+			queue = append(queue, decoder.flush()...)
+		case r := <-raw:
+			queue = append(queue, decoder.decode(r.input)...)
+
+			timeout = nil
+			if r.err != nil {
+				queue = append(queue, decoder.flush()...)
+				if r.err != io.EOF {
+					finalErr = r.err
+				}
+				// the reading goroutine has exited
+				raw = nil
+				continue
+			}
+			if decoder.hasPending() {
+				timeout = time.After(t.escapeTimeout)
+			}
+		}
+	}
 }
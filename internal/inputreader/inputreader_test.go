@@ -1,16 +1,18 @@
 package inputreader
 
 import (
+	"context"
 	"io"
+	"runtime"
 	"testing"
 	"time"
 )
 
 func TestInputReader(t *testing.T) {
 	var (
-		done     = make(chan bool)
-		writeErr = make(chan error)
-		recieved = []byte{}
+		ctx, cancel = context.WithCancel(context.Background())
+		writeErr    = make(chan error)
+		recieved    = []byte{}
 	)
 
 	tReader, tWriter := io.Pipe()
@@ -19,12 +21,12 @@ func TestInputReader(t *testing.T) {
 
 	testInputs := "hjkllkjh"
 
-	inputs, readErr := reader.ReadInput(done)
+	inputs, readErr := reader.ReadInput(ctx)
 	go func() {
 		defer func() {
 			tWriter.Close()
-			time.Sleep(10 * time.Millisecond) // give ReadInput go routine enough time to read last input following 'done'
-			close(done)
+			time.Sleep(10 * time.Millisecond) // give ReadInput go routine enough time to read last input before cancelling
+			cancel()
 		}()
 		for _, b := range []byte(testInputs) {
 			_, err := tWriter.Write([]byte{b})
@@ -46,7 +48,7 @@ func TestInputReader(t *testing.T) {
 			if err != io.EOF {
 				t.Fatalf("Unexpected error reading input: %s", err)
 			}
-		case <-done:
+		case <-ctx.Done():
 			if string(recieved) != testInputs {
 				t.Errorf("Unexpected input read [expected = %s, actual = %s]", testInputs, string(recieved))
 			}
@@ -54,3 +56,199 @@ func TestInputReader(t *testing.T) {
 		}
 	}
 }
+
+func TestInputReaderSequentialConsumers(t *testing.T) {
+	var (
+		writeErr = make(chan error)
+		expected = []string{"hjkl", "lkjh"}
+	)
+
+	tReader, tWriter := io.Pipe()
+	defer tWriter.Close()
+
+	reader := NewTermReader(tReader)
+
+	for i, testInputs := range expected {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			recieved    = []byte{}
+		)
+
+		inputs, readErr := reader.ReadInput(ctx)
+		go func(testInputs string) {
+			for _, b := range []byte(testInputs) {
+				_, err := tWriter.Write([]byte{b})
+				if err != nil {
+					writeErr <- err
+					return
+				}
+			}
+		}(testInputs)
+
+		for len(recieved) != len(testInputs) {
+			select {
+			case input := <-inputs:
+				recieved = append(recieved, input...)
+			case err := <-writeErr:
+				t.Fatalf("Unexpected error writing input for consumer %d: %s", i, err)
+			case err := <-readErr:
+				t.Fatalf("Unexpected error reading input for consumer %d: %s", i, err)
+			case <-time.After(500 * time.Millisecond):
+				t.Fatalf("Timed out waiting for input for consumer %d (recieved = %s)", i, string(recieved))
+			}
+		}
+		cancel()
+
+		if string(recieved) != testInputs {
+			t.Errorf("Unexpected input read for consumer %d [expected = %s, actual = %s]", i, testInputs, string(recieved))
+		}
+	}
+}
+
+func TestInputReaderKeys(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		writeErr    = make(chan error)
+		// the lone ESC is only reported once the escape timeout expires
+		writes   = []string{"hj\u001b[A", "\u001b", "[B", "\u001b"}
+		expected = []string{"h", "j", "\u001b[A", "\u001b[B", "\u001b"}
+		recieved = []string{}
+	)
+	defer cancel()
+
+	tReader, tWriter := io.Pipe()
+	defer tWriter.Close()
+
+	reader := NewTermReader(tReader)
+
+	inputs, readErr := reader.ReadInput(ctx)
+	go func() {
+		for _, w := range writes {
+			if _, err := tWriter.Write([]byte(w)); err != nil {
+				writeErr <- err
+				return
+			}
+		}
+	}()
+
+	for len(recieved) != len(expected) {
+		select {
+		case input := <-inputs:
+			recieved = append(recieved, string(input))
+		case err := <-writeErr:
+			t.Fatalf("Unexpected error writing input: %s", err)
+		case err := <-readErr:
+			t.Fatalf("Unexpected error reading input: %s", err)
+		case <-time.After(500 * time.Millisecond):
+			t.Fatalf("Timed out waiting for input (recieved = %q)", recieved)
+		}
+	}
+
+	for i := range expected {
+		if recieved[i] != expected[i] {
+			t.Errorf("Unexpected key %d [expected = %q, actual = %q]", i, expected[i], recieved[i])
+		}
+	}
+}
+
+func TestInputReaderCancelled(t *testing.T) {
+	tReader, tWriter := io.Pipe()
+	defer tWriter.Close()
+
+	reader := NewTermReader(tReader)
+
+	// input which isn't received before the context is cancelled is kept for the next consumer
+	ctx, cancel := context.WithCancel(context.Background())
+	reader.ReadInput(ctx)
+	if _, err := tWriter.Write([]byte("h")); err != nil {
+		t.Fatalf("Unexpected error writing input: %s", err)
+	}
+	cancel()
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	inputs, readErr := reader.ReadInput(ctx)
+	select {
+	case input := <-inputs:
+		if string(input) != "h" {
+			t.Errorf("Unexpected input read [expected = h, actual = %s]", string(input))
+		}
+	case err := <-readErr:
+		t.Fatalf("Unexpected error reading input: %s", err)
+	case <-time.After(500 * time.Millisecond):
+		t.Fatalf("Timed out waiting for input")
+	}
+}
+
+func TestInputReaderUnread(t *testing.T) {
+	tReader, tWriter := io.Pipe()
+	defer tWriter.Close()
+
+	reader := NewTermReader(tReader)
+
+	// receive reads the next key sent to the consumer
+	receive := func(consumer int, inputs <-chan []byte, readErr <-chan error) string {
+		select {
+		case input := <-inputs:
+			return string(input)
+		case err := <-readErr:
+			t.Fatalf("Unexpected error reading input for consumer %d: %s", consumer, err)
+		case <-time.After(500 * time.Millisecond):
+			t.Fatalf("Timed out waiting for input for consumer %d", consumer)
+		}
+		return ""
+	}
+
+	// the first consumer is cancelled while handling the first key, so returns it to the reader
+	ctx, cancel := context.WithCancel(context.Background())
+	inputs, readErr := reader.ReadInput(ctx)
+	if _, err := tWriter.Write([]byte("hj")); err != nil {
+		t.Fatalf("Unexpected error writing input: %s", err)
+	}
+	if key := receive(1, inputs, readErr); key != "h" {
+		t.Fatalf("Unexpected input read for consumer 1 [expected = h, actual = %s]", key)
+	}
+	cancel()
+	reader.Unread([]byte("h"))
+
+	// the second consumer receives the returned key before the key which was still queued
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	inputs, readErr = reader.ReadInput(ctx)
+	for _, expected := range []string{"h", "j"} {
+		if key := receive(2, inputs, readErr); key != expected {
+			t.Errorf("Unexpected input read for consumer 2 [expected = %s, actual = %s]", expected, key)
+		}
+	}
+}
+
+func TestInputReaderClose(t *testing.T) {
+	tReader, tWriter := io.Pipe()
+	defer tWriter.Close()
+
+	goroutines := runtime.NumGoroutine()
+	reader := NewTermReader(tReader)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reader.ReadInput(ctx)
+
+	if err := reader.Close(); err != nil {
+		t.Fatalf("Unexpected error closing reader: %s", err)
+	}
+	// the pending read is interrupted by closing the terminal, so every goroutine should have exited
+	if running := runtime.NumGoroutine(); running > goroutines {
+		t.Errorf("Unexpected goroutines still running [before = %d, after = %d]", goroutines, running)
+	}
+
+	_, readErr := reader.ReadInput(ctx)
+	select {
+	case err := <-readErr:
+		if err != ErrClosed {
+			t.Errorf("Unexpected error reading after closing [expected = %v, actual = %v]", ErrClosed, err)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatalf("Timed out waiting for error")
+	}
+}
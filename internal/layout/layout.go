@@ -0,0 +1,133 @@
+// Package layout arranges blocks of cells (e.g. boxes) relative to each other
+// every function returns a rectangular block of cells, padding with blank cells where needed
+package layout
+
+import "github.com/ShawnROGrady/gotris/internal/canvas"
+
+// Alignment specifies where a smaller block is placed within the space available to it
+type Alignment int
+
+// the available alignments
+const (
+	Start  Alignment = iota // top or left
+	Center                  // centered, rounding towards the start
+	End                     // bottom or right
+)
+
+func (a Alignment) String() string {
+	alignmentNames := map[Alignment]string{
+		Start:  "start",
+		Center: "center",
+		End:    "end",
+	}
+
+	return alignmentNames[a]
+}
+
+// offset is how much of the extra space comes before the block
+func (a Alignment) offset(extra int) int {
+	switch a {
+	case Center:
+		return extra / 2
+	case End:
+		return extra
+	default:
+		return 0
+	}
+}
+
+// Width is the length of the widest row of cells
+func Width(cells [][]canvas.Cell) int {
+	var width int
+	for _, row := range cells {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	return width
+}
+
+// Height is the number of rows of cells
+func Height(cells [][]canvas.Cell) int {
+	return len(cells)
+}
+
+// blank is the cell used for padding
+func blank() canvas.Cell {
+	return &canvas.TextCell{Text: " ", Color: canvas.Reset}
+}
+
+func blankRow(width int) []canvas.Cell {
+	row := make([]canvas.Cell, width)
+	for i := range row {
+		row[i] = blank()
+	}
+	return row
+}
+
+// Pad surrounds the cells with the specified number of blank rows and columns
+func Pad(cells [][]canvas.Cell, top, right, bottom, left int) [][]canvas.Cell {
+	var (
+		width  = Width(cells)
+		padded = make([][]canvas.Cell, 0, top+len(cells)+bottom)
+	)
+	for i := 0; i < top; i++ {
+		padded = append(padded, blankRow(left+width+right))
+	}
+	for _, row := range cells {
+		paddedRow := make([]canvas.Cell, 0, left+width+right)
+		paddedRow = append(paddedRow, blankRow(left)...)
+		paddedRow = append(paddedRow, row...)
+		paddedRow = append(paddedRow, blankRow(width-len(row)+right)...)
+		padded = append(padded, paddedRow)
+	}
+	for i := 0; i < bottom; i++ {
+		padded = append(padded, blankRow(left+width+right))
+	}
+	return padded
+}
+
+// HStack places each block to the right of the previous one
+// blocks shorter than the tallest block are aligned vertically using the specified alignment
+func HStack(align Alignment, blocks ...[][]canvas.Cell) [][]canvas.Cell {
+	var height int
+	for _, block := range blocks {
+		if Height(block) > height {
+			height = Height(block)
+		}
+	}
+
+	cells := make([][]canvas.Cell, height)
+	for _, block := range blocks {
+		var (
+			extra  = height - Height(block)
+			top    = align.offset(extra)
+			padded = Pad(block, top, 0, extra-top, 0)
+		)
+		for i := range cells {
+			cells[i] = append(cells[i], padded[i]...)
+		}
+	}
+	return cells
+}
+
+// VStack places each block below the previous one
+// blocks narrower than the widest block are aligned horizontally using the specified alignment
+func VStack(align Alignment, blocks ...[][]canvas.Cell) [][]canvas.Cell {
+	var width int
+	for _, block := range blocks {
+		if Width(block) > width {
+			width = Width(block)
+		}
+	}
+
+	cells := [][]canvas.Cell{}
+	for _, block := range blocks {
+		var (
+			extra = width - Width(block)
+			left  = align.offset(extra)
+		)
+		cells = append(cells, Pad(block, 0, extra-left, 0, left)...)
+	}
+	return cells
+}
@@ -0,0 +1,83 @@
+package layout
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ShawnROGrady/gotris/internal/canvas"
+)
+
+// text converts the cells back to a string, so the expected layouts are easier to read
+func text(cells [][]canvas.Cell) string {
+	lines := []string{}
+	for _, row := range cells {
+		var b strings.Builder
+		for _, cell := range row {
+			b.WriteString(cell.(*canvas.TextCell).Text)
+		}
+		lines = append(lines, b.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func block(s string) [][]canvas.Cell {
+	return canvas.CellsFromString(s, canvas.White)
+}
+
+var layoutTests = map[string]struct {
+	cells    [][]canvas.Cell
+	expected string
+}{
+	"pad": {
+		cells:    Pad(block("ab\nc"), 1, 2, 1, 1),
+		expected: "     \n ab  \n c   \n     ",
+	},
+	"pad nothing": {
+		cells:    Pad(block("ab\ncd"), 0, 0, 0, 0),
+		expected: "ab\ncd",
+	},
+	"hstack start": {
+		cells:    HStack(Start, block("a\na\na"), block("b"), block("c\nc")),
+		expected: "abc\na c\na  ",
+	},
+	"hstack center": {
+		cells:    HStack(Center, block("a\na\na"), block("b"), block("c\nc")),
+		expected: "a c\nabc\na  ",
+	},
+	"hstack end": {
+		cells:    HStack(End, block("a\na\na"), block("b"), block("c\nc")),
+		expected: "a  \na c\nabc",
+	},
+	"hstack ragged rows": {
+		cells:    HStack(Start, [][]canvas.Cell{block("aa")[0], block("a")[0]}, block("b\nb")),
+		expected: "aab\na b",
+	},
+	"vstack start": {
+		cells:    VStack(Start, block("aaa"), block("b"), block("cc")),
+		expected: "aaa\nb  \ncc ",
+	},
+	"vstack center": {
+		cells:    VStack(Center, block("aaa"), block("b"), block("cc")),
+		expected: "aaa\n b \ncc ",
+	},
+	"vstack end": {
+		cells:    VStack(End, block("aaa"), block("b"), block("cc")),
+		expected: "aaa\n  b\n cc",
+	},
+	"nested": {
+		cells:    HStack(Start, block("a\na"), VStack(End, block("bb"), block("c"))),
+		expected: "abb\na c",
+	},
+	"empty": {
+		cells:    HStack(Start),
+		expected: "",
+	},
+}
+
+func TestLayout(t *testing.T) {
+	for testName, test := range layoutTests {
+		if actual := text(test.cells); actual != test.expected {
+			t.Errorf("Unexpected layout for test case '%s' [expected = %q, actual = %q]", testName, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+// +build linux darwin freebsd netbsd openbsd dragonfly
+
+// Package term provides control over the state of the terminal, using termios through ioctl syscalls
+package term
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// State is the state of a terminal, which can be used to restore the terminal after changing it
+type State struct {
+	termios syscall.Termios
+}
+
+// GetState returns the current state of the terminal
+func GetState(fd uintptr) (*State, error) {
+	var s State
+	if err := ioctl(fd, ioctlGetTermios, &s.termios); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// MakeGameMode puts the terminal into the mode used while playing, returning the previous state
+// input is available as soon as a single byte is typed and isn't echoed, but signals (e.g. ctrl+c) are still generated
+// output flow control is disabled, so ctrl+s and ctrl+q are read like any other key rather than pausing the output
+func MakeGameMode(fd uintptr) (*State, error) {
+	oldState, err := GetState(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	termios := oldState.termios
+	termios.Lflag &^= syscall.ICANON | syscall.ECHO | syscall.ECHOE | syscall.ECHOK | syscall.ECHONL
+	termios.Iflag &^= syscall.IXON
+	termios.Cc[syscall.VMIN] = 1
+	termios.Cc[syscall.VTIME] = 0
+
+	if err := ioctl(fd, ioctlSetTermios, &termios); err != nil {
+		return nil, err
+	}
+	return oldState, nil
+}
+
+// Restore returns the terminal to a previous state
+func Restore(fd uintptr, state *State) error {
+	return ioctl(fd, ioctlSetTermios, &state.termios)
+}
+
+// winsize matches struct winsize from sys/ioctl.h
+type winsize struct {
+	rows   uint16
+	cols   uint16
+	xPixel uint16
+	yPixel uint16
+}
+
+// Size returns the width and height of the terminal, in cells
+func Size(fd uintptr) (width, height int, err error) {
+	var ws winsize
+	if err := ioctlPtr(fd, syscall.TIOCGWINSZ, unsafe.Pointer(&ws)); err != nil {
+		return 0, 0, err
+	}
+	return int(ws.cols), int(ws.rows), nil
+}
+
+func ioctl(fd uintptr, request uintptr, termios *syscall.Termios) error {
+	return ioctlPtr(fd, request, unsafe.Pointer(termios))
+}
+
+func ioctlPtr(fd uintptr, request uintptr, arg unsafe.Pointer) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, request, uintptr(arg))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
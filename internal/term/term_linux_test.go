@@ -0,0 +1,123 @@
+package term
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+	"unsafe"
+)
+
+// openPty opens a new pseudo-terminal, returning the terminal (slave) side
+func openPty(t *testing.T) (*os.File, func()) {
+	ptmx, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		t.Skipf("Unable to open pseudo-terminal: %s", err)
+	}
+
+	var (
+		unlock int32
+		ptyNum uint32
+	)
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, ptmx.Fd(), syscall.TIOCSPTLCK, uintptr(unsafe.Pointer(&unlock))); errno != 0 {
+		ptmx.Close()
+		t.Skipf("Unable to unlock pseudo-terminal: %s", errno)
+	}
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, ptmx.Fd(), syscall.TIOCGPTN, uintptr(unsafe.Pointer(&ptyNum))); errno != 0 {
+		ptmx.Close()
+		t.Skipf("Unable to get pseudo-terminal number: %s", errno)
+	}
+
+	pts, err := os.OpenFile(fmt.Sprintf("/dev/pts/%d", ptyNum), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		ptmx.Close()
+		t.Skipf("Unable to open pseudo-terminal: %s", err)
+	}
+
+	return pts, func() {
+		pts.Close()
+		ptmx.Close()
+	}
+}
+
+func TestMakeGameModeRestore(t *testing.T) {
+	pts, closePty := openPty(t)
+	defer closePty()
+
+	original, err := GetState(pts.Fd())
+	if err != nil {
+		t.Fatalf("Unexpected error getting initial state: %s", err)
+	}
+	if original.termios.Lflag&syscall.ICANON == 0 || original.termios.Lflag&syscall.ECHO == 0 {
+		t.Fatalf("Unexpected initial state, expected canonical mode with echo [lflag = %b]", original.termios.Lflag)
+	}
+	if original.termios.Iflag&syscall.IXON == 0 {
+		t.Fatalf("Unexpected initial state, expected flow control [iflag = %b]", original.termios.Iflag)
+	}
+
+	oldState, err := MakeGameMode(pts.Fd())
+	if err != nil {
+		t.Fatalf("Unexpected error entering game mode: %s", err)
+	}
+	if oldState.termios != original.termios {
+		t.Errorf("Unexpected previous state returned [expected = %+v, actual = %+v]", original.termios, oldState.termios)
+	}
+
+	gameState, err := GetState(pts.Fd())
+	if err != nil {
+		t.Fatalf("Unexpected error getting game mode state: %s", err)
+	}
+	if gameState.termios.Lflag&(syscall.ICANON|syscall.ECHO) != 0 {
+		t.Errorf("Unexpected canonical mode or echo enabled in game mode [lflag = %b]", gameState.termios.Lflag)
+	}
+	if gameState.termios.Iflag&syscall.IXON != 0 {
+		t.Errorf("Unexpected flow control enabled in game mode [iflag = %b]", gameState.termios.Iflag)
+	}
+	if gameState.termios.Lflag&syscall.ISIG == 0 {
+		t.Errorf("Unexpectedly disabled signals in game mode [lflag = %b]", gameState.termios.Lflag)
+	}
+	if gameState.termios.Cc[syscall.VMIN] != 1 || gameState.termios.Cc[syscall.VTIME] != 0 {
+		t.Errorf("Unexpected VMIN/VTIME in game mode [expected = 1/0, actual = %d/%d]", gameState.termios.Cc[syscall.VMIN], gameState.termios.Cc[syscall.VTIME])
+	}
+
+	if err := Restore(pts.Fd(), oldState); err != nil {
+		t.Fatalf("Unexpected error restoring state: %s", err)
+	}
+	restored, err := GetState(pts.Fd())
+	if err != nil {
+		t.Fatalf("Unexpected error getting restored state: %s", err)
+	}
+	if restored.termios != original.termios {
+		t.Errorf("Unexpected restored state [expected = %+v, actual = %+v]", original.termios, restored.termios)
+	}
+}
+
+func TestGetStateNotTerminal(t *testing.T) {
+	f, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatalf("Unexpected error opening %s: %s", os.DevNull, err)
+	}
+	defer f.Close()
+
+	if _, err := GetState(f.Fd()); err == nil {
+		t.Errorf("Unexpectedly no error getting state of non-terminal")
+	}
+}
+
+func TestSize(t *testing.T) {
+	pts, closePty := openPty(t)
+	defer closePty()
+
+	ws := winsize{rows: 24, cols: 80}
+	if err := ioctlPtr(pts.Fd(), syscall.TIOCSWINSZ, unsafe.Pointer(&ws)); err != nil {
+		t.Fatalf("Unexpected error setting size: %s", err)
+	}
+
+	width, height, err := Size(pts.Fd())
+	if err != nil {
+		t.Fatalf("Unexpected error getting size: %s", err)
+	}
+	if width != 80 || height != 24 {
+		t.Errorf("Unexpected size [expected = 80x24, actual = %dx%d]", width, height)
+	}
+}
@@ -0,0 +1,34 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd,!dragonfly
+
+package term
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// State is the state of a terminal, which can be used to restore the terminal after changing it
+type State struct{}
+
+var errUnsupported = fmt.Errorf("terminal control is not supported on %s", runtime.GOOS)
+
+// GetState returns the current state of the terminal
+func GetState(fd uintptr) (*State, error) {
+	return nil, errUnsupported
+}
+
+// MakeGameMode puts the terminal into the mode used while playing, returning the previous state
+func MakeGameMode(fd uintptr) (*State, error) {
+	return nil, errUnsupported
+}
+
+// Restore returns the terminal to a previous state
+func Restore(fd uintptr, state *State) error {
+	return errUnsupported
+}
+
+// Size returns the width and height of the terminal, in cells
+func Size(fd uintptr) (width, height int, err error) {
+	return 0, 0, errUnsupported
+}
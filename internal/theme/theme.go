@@ -0,0 +1,252 @@
+// Package theme defines the colors used to display the game
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ShawnROGrady/gotris/internal/canvas"
+	"github.com/ShawnROGrady/gotris/internal/game/tetrimino"
+)
+
+// the names of the built in themes
+const (
+	ClassicName          = "classic"
+	LowContrastName      = "low-contrast"
+	LightName            = "light"
+	LightLowContrastName = "light-low-contrast"
+	GuidelineName        = "guideline"
+	SolarizedName        = "solarized"
+)
+
+// Theme specifies every color used to display the game
+type Theme struct {
+	Name       string
+	Background canvas.Color // the background of the board
+	Text       canvas.Color
+	Border     canvas.Color
+	Caption    canvas.Color
+	Ghost      canvas.GhostStyle
+	Pieces     map[tetrimino.Kind]canvas.Color
+}
+
+// BoxStyle is the style used to draw boxes (e.g. around the board)
+func (t Theme) BoxStyle() canvas.BoxStyle {
+	return canvas.BoxStyle{Border: t.Border, Caption: t.Caption}
+}
+
+func (t Theme) String() string {
+	return t.Name
+}
+
+func classicPieces() map[tetrimino.Kind]canvas.Color {
+	return map[tetrimino.Kind]canvas.Color{
+		tetrimino.IPiece: canvas.Cyan,
+		tetrimino.JPiece: canvas.Blue,
+		tetrimino.LPiece: canvas.Orange,
+		tetrimino.OPiece: canvas.Yellow,
+		tetrimino.SPiece: canvas.Green,
+		tetrimino.TPiece: canvas.Magenta,
+		tetrimino.ZPiece: canvas.Red,
+	}
+}
+
+// Classic is the default theme, intended for terminals with a dark background
+func Classic() Theme {
+	return Theme{
+		Name:       ClassicName,
+		Background: canvas.White,
+		Text:       canvas.White,
+		Pieces:     classicPieces(),
+	}
+}
+
+// LowContrast is the classic theme with a black board
+func LowContrast() Theme {
+	t := Classic()
+	t.Name = LowContrastName
+	t.Background = canvas.Black
+	return t
+}
+
+// Light is intended for terminals with a light background
+func Light() Theme {
+	t := Classic()
+	t.Name = LightName
+	t.Background = canvas.Black
+	t.Text = canvas.Black
+	return t
+}
+
+// LightLowContrast is the light theme with a white board
+func LightLowContrast() Theme {
+	t := Light()
+	t.Name = LightLowContrastName
+	t.Background = canvas.White
+	return t
+}
+
+// Guideline uses the exact piece colors from the Tetris guideline, and requires a terminal which supports 24-bit colors
+// on other terminals the closest supported colors are used instead
+func Guideline() Theme {
+	return Theme{
+		Name:       GuidelineName,
+		Background: canvas.RGB(24, 24, 24),
+		Text:       canvas.BrightWhite,
+		Border:     canvas.BrightBlack,
+		Caption:    canvas.BrightWhite,
+		Pieces: map[tetrimino.Kind]canvas.Color{
+			tetrimino.IPiece: canvas.RGB(0, 240, 240),
+			tetrimino.JPiece: canvas.RGB(0, 0, 240),
+			tetrimino.LPiece: canvas.RGB(240, 160, 0),
+			tetrimino.OPiece: canvas.RGB(240, 240, 0),
+			tetrimino.SPiece: canvas.RGB(0, 240, 0),
+			tetrimino.TPiece: canvas.RGB(160, 0, 240),
+			tetrimino.ZPiece: canvas.RGB(240, 0, 0),
+		},
+	}
+}
+
+// Solarized uses the solarized dark palette: https://ethanschoonover.com/solarized/
+func Solarized() Theme {
+	return Theme{
+		Name:       SolarizedName,
+		Background: canvas.RGB(0x00, 0x2b, 0x36),
+		Text:       canvas.RGB(0x93, 0xa1, 0xa1),
+		Border:     canvas.RGB(0x58, 0x6e, 0x75),
+		Caption:    canvas.RGB(0xb5, 0x89, 0x00),
+		Ghost:      canvas.LightGhost,
+		Pieces: map[tetrimino.Kind]canvas.Color{
+			tetrimino.IPiece: canvas.RGB(0x2a, 0xa1, 0x98),
+			tetrimino.JPiece: canvas.RGB(0x26, 0x8b, 0xd2),
+			tetrimino.LPiece: canvas.RGB(0xcb, 0x4b, 0x16),
+			tetrimino.OPiece: canvas.RGB(0xb5, 0x89, 0x00),
+			tetrimino.SPiece: canvas.RGB(0x85, 0x99, 0x00),
+			tetrimino.TPiece: canvas.RGB(0x6c, 0x71, 0xc4),
+			tetrimino.ZPiece: canvas.RGB(0xdc, 0x32, 0x2f),
+		},
+	}
+}
+
+// Available returns all built in themes
+func Available() []Theme {
+	return []Theme{Classic(), LowContrast(), Light(), LightLowContrast(), Guideline(), Solarized()}
+}
+
+// FromName retrieves the built in theme associated with the specified name
+func FromName(name string) (Theme, error) {
+	for _, t := range Available() {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return Theme{}, fmt.Errorf("unrecognized theme: '%s'", name)
+}
+
+// file is the format of a theme file
+// every field is optional, any which are missing are taken from the base theme (classic by default)
+type file struct {
+	Name       string            `json:"name"`
+	Base       string            `json:"base"`
+	Background string            `json:"background"`
+	Text       string            `json:"text"`
+	Border     string            `json:"border"`
+	Caption    string            `json:"caption"`
+	Ghost      string            `json:"ghost"`
+	Pieces     map[string]string `json:"pieces"`
+}
+
+// Load reads a theme from the specified file
+// if the file doesn't specify a name, the name of the file (without its extension) is used
+func Load(path string) (Theme, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Theme{}, err
+	}
+
+	var f file
+	if err := json.Unmarshal(contents, &f); err != nil {
+		return Theme{}, fmt.Errorf("error parsing theme from '%s': %s", path, err)
+	}
+	if f.Name == "" {
+		f.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	t, err := f.theme()
+	if err != nil {
+		return Theme{}, fmt.Errorf("invalid theme '%s': %s", path, err)
+	}
+	return t, nil
+}
+
+func (f file) theme() (Theme, error) {
+	base := f.Base
+	if base == "" {
+		base = ClassicName
+	}
+	t, err := FromName(base)
+	if err != nil {
+		return Theme{}, err
+	}
+	t.Name = f.Name
+
+	colors := []struct {
+		value string
+		dest  *canvas.Color
+	}{
+		{f.Background, &t.Background},
+		{f.Text, &t.Text},
+		{f.Border, &t.Border},
+		{f.Caption, &t.Caption},
+	}
+	for _, c := range colors {
+		if c.value == "" {
+			continue
+		}
+		if *c.dest, err = canvas.ParseColor(c.value); err != nil {
+			return Theme{}, err
+		}
+	}
+
+	if f.Ghost != "" {
+		if t.Ghost, err = canvas.GhostStyleFromName(f.Ghost); err != nil {
+			return Theme{}, err
+		}
+	}
+
+	// sort to report errors consistently
+	names := []string{}
+	for name := range f.Pieces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		kind, err := tetrimino.KindFromName(name)
+		if err != nil {
+			return Theme{}, err
+		}
+		color, err := canvas.ParseColor(f.Pieces[name])
+		if err != nil {
+			return Theme{}, err
+		}
+		t.Pieces[kind] = color
+	}
+	return t, nil
+}
+
+// Lookup retrieves either the built in theme with the specified name, or the theme stored in the file at the specified path
+func Lookup(nameOrPath string) (Theme, error) {
+	if t, err := FromName(nameOrPath); err == nil {
+		return t, nil
+	}
+	if !strings.ContainsRune(nameOrPath, filepath.Separator) && filepath.Ext(nameOrPath) == "" {
+		// not a path, so report the unrecognized name instead of a missing file
+		return FromName(nameOrPath)
+	}
+	return Load(nameOrPath)
+}
@@ -0,0 +1,167 @@
+package theme
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ShawnROGrady/gotris/internal/canvas"
+	"github.com/ShawnROGrady/gotris/internal/game/tetrimino"
+)
+
+func TestAvailable(t *testing.T) {
+	for _, expected := range Available() {
+		if len(expected.Pieces) != len(tetrimino.Kinds()) {
+			t.Errorf("Unexpected number of piece colors for theme '%s' [expected = %d, actual = %d]", expected, len(tetrimino.Kinds()), len(expected.Pieces))
+		}
+
+		theme, err := FromName(expected.Name)
+		if err != nil {
+			t.Errorf("Unexpected error finding theme '%s': %s", expected, err)
+			continue
+		}
+		if !reflect.DeepEqual(theme, expected) {
+			t.Errorf("Unexpected theme for name '%s' [expected = %+v, actual = %+v]", expected, expected, theme)
+		}
+	}
+
+	if _, err := FromName("unknown"); err == nil {
+		t.Errorf("Unexpectedly no error finding theme 'unknown'")
+	}
+}
+
+func withPieces(t Theme, pieces map[tetrimino.Kind]canvas.Color) Theme {
+	for kind, color := range pieces {
+		t.Pieces[kind] = color
+	}
+	return t
+}
+
+var loadTests = map[string]struct {
+	contents    string
+	expectedErr bool
+	expected    Theme
+}{
+	"empty file": {
+		contents: `{}`,
+		expected: func() Theme {
+			t := Classic()
+			t.Name = "empty file"
+			return t
+		}(),
+	},
+	"full file": {
+		contents: `{
+			"name": "mine",
+			"background": "black",
+			"text": "bright white",
+			"border": "#586e75",
+			"caption": "208",
+			"ghost": "dark",
+			"pieces": {"I": "#00f0f0", "t": "magenta"}
+		}`,
+		expected: withPieces(Theme{
+			Name:       "mine",
+			Background: canvas.Black,
+			Text:       canvas.BrightWhite,
+			Border:     canvas.RGB(0x58, 0x6e, 0x75),
+			Caption:    canvas.Orange,
+			Ghost:      canvas.DarkGhost,
+			Pieces:     classicPieces(),
+		}, map[tetrimino.Kind]canvas.Color{
+			tetrimino.IPiece: canvas.RGB(0, 240, 240),
+			tetrimino.TPiece: canvas.Magenta,
+		}),
+	},
+	"with base": {
+		contents: `{"name": "dim solarized", "base": "solarized", "ghost": "medium"}`,
+		expected: func() Theme {
+			t := Solarized()
+			t.Name = "dim solarized"
+			t.Ghost = canvas.MediumGhost
+			return t
+		}(),
+	},
+	"invalid json": {
+		contents:    `{"name": `,
+		expectedErr: true,
+	},
+	"invalid base": {
+		contents:    `{"base": "unknown"}`,
+		expectedErr: true,
+	},
+	"invalid color": {
+		contents:    `{"border": "not a color"}`,
+		expectedErr: true,
+	},
+	"invalid ghost": {
+		contents:    `{"ghost": "invisible"}`,
+		expectedErr: true,
+	},
+	"invalid piece": {
+		contents:    `{"pieces": {"X": "red"}}`,
+		expectedErr: true,
+	},
+	"invalid piece color": {
+		contents:    `{"pieces": {"I": "#00f0f"}}`,
+		expectedErr: true,
+	},
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotris")
+	if err != nil {
+		t.Fatalf("Unexpected error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for testName, test := range loadTests {
+		path := filepath.Join(dir, testName+".json")
+		if err := ioutil.WriteFile(path, []byte(test.contents), 0644); err != nil {
+			t.Fatalf("Unexpected error writing theme for test case '%s': %s", testName, err)
+		}
+
+		theme, err := Load(path)
+		if test.expectedErr {
+			if err == nil {
+				t.Errorf("Unexpectedly no error for test case '%s'", testName)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for test case '%s': %s", testName, err)
+			continue
+		}
+		if !reflect.DeepEqual(theme, test.expected) {
+			t.Errorf("Unexpected theme for test case '%s' [expected = %+v, actual = %+v]", testName, test.expected, theme)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotris")
+	if err != nil {
+		t.Fatalf("Unexpected error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "custom.json")
+	if err := ioutil.WriteFile(path, []byte(`{"base": "light"}`), 0644); err != nil {
+		t.Fatalf("Unexpected error writing theme: %s", err)
+	}
+
+	if theme, err := Lookup(GuidelineName); err != nil || theme.Name != GuidelineName {
+		t.Errorf("Unexpected result looking up built in theme [theme = %s, err = %v]", theme, err)
+	}
+	if theme, err := Lookup(path); err != nil || theme.Name != "custom" {
+		t.Errorf("Unexpected result looking up theme file [theme = %s, err = %v]", theme, err)
+	}
+	if _, err := Lookup("unknown"); err == nil || err.Error() != "unrecognized theme: 'unknown'" {
+		t.Errorf("Unexpected error looking up unknown theme: %v", err)
+	}
+	if _, err := Lookup(filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("Unexpectedly no error looking up missing theme file")
+	}
+}
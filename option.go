@@ -0,0 +1,72 @@
+package gotris
+
+import (
+	"fmt"
+
+	"github.com/ShawnROGrady/gotris/internal/game"
+	"github.com/ShawnROGrady/gotris/internal/game/tetrimino"
+)
+
+// Option configures a new game
+type Option struct {
+	options []game.Option
+	err     error
+}
+
+// Mode determines how a game can end, other than by topping out
+type Mode string
+
+// the available modes
+const (
+	// Marathon continues until topping out
+	Marathon Mode = game.MarathonMode
+	// Sprint ends after clearing 40 lines
+	Sprint Mode = game.SprintMode
+	// Ultra ends after 2 minutes, see Game.TimeUp
+	Ultra Mode = game.UltraMode
+)
+
+// WithMode returns an option which specifies the mode (default Marathon)
+func WithMode(m Mode) Option {
+	mode, err := game.ModeFromName(string(m))
+	if err != nil {
+		return Option{err: err}
+	}
+	return Option{options: []game.Option{game.WithMode(mode)}}
+}
+
+// WithBoardSize returns an option which specifies the number of columns and visible rows of the board (default 10x20)
+func WithBoardSize(width, height int) Option {
+	// every piece must fit on the board
+	if width < tetrimino.MaxWidth || height < tetrimino.MaxHeight {
+		return Option{err: fmt.Errorf("invalid board size: %dx%d (the minimum is %dx%d)", width, height, tetrimino.MaxWidth, tetrimino.MaxHeight)}
+	}
+	return Option{options: []game.Option{game.WithBoardSize(width, height)}}
+}
+
+// WithHiddenRows returns an option which specifies the number of rows above the visible field, where pieces spawn (default 4)
+func WithHiddenRows(rows int) Option {
+	if rows < 0 {
+		return Option{err: fmt.Errorf("invalid number of hidden rows: %d", rows)}
+	}
+	return Option{options: []game.Option{game.WithHiddenRows(rows)}}
+}
+
+// WithInitialLevel returns an option which specifies the level the game starts at (default 0)
+func WithInitialLevel(level int) Option {
+	if level < 0 {
+		return Option{err: fmt.Errorf("invalid initial level: %d", level)}
+	}
+	return Option{options: []game.Option{game.WithInitialLevel(level)}}
+}
+
+// WithSeed returns an option which specifies the seed used to generate the order of the pieces (default random)
+// games with the same seed get the same sequence of pieces, see Result.Seed
+func WithSeed(seed int64) Option {
+	return Option{options: []game.Option{game.WithSeed(seed)}}
+}
+
+// WithPartialLockOut returns an option which ends the game if a piece locks partially above the visible field
+func WithPartialLockOut() Option {
+	return Option{options: []game.Option{game.WithPartialLockOut()}}
+}
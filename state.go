@@ -0,0 +1,272 @@
+package gotris
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ShawnROGrady/gotris/internal/game"
+	"github.com/ShawnROGrady/gotris/internal/game/tetrimino"
+)
+
+// Shape identifies the shape of a piece, and which piece each block of the board belongs to
+type Shape int
+
+// the available shapes
+const (
+	// Empty is used for the spaces of the board without a block
+	Empty Shape = iota
+	I
+	J
+	L
+	O
+	S
+	T
+	Z
+)
+
+// Shapes returns the shape of every piece
+func Shapes() []Shape {
+	return []Shape{I, J, L, O, S, T, Z}
+}
+
+func (s Shape) String() string {
+	kind, ok := internalKinds[s]
+	if !ok {
+		return ""
+	}
+	return kind.String()
+}
+
+// MarshalText encodes the shape using its letter, so shapes can be used as keys of JSON objects
+func (s Shape) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+var internalKinds = map[Shape]tetrimino.Kind{
+	I: tetrimino.IPiece,
+	J: tetrimino.JPiece,
+	L: tetrimino.LPiece,
+	O: tetrimino.OPiece,
+	S: tetrimino.SPiece,
+	T: tetrimino.TPiece,
+	Z: tetrimino.ZPiece,
+}
+
+func shapeOf(kind tetrimino.Kind) Shape {
+	for shape, k := range internalKinds {
+		if k == kind {
+			return shape
+		}
+	}
+	return Empty
+}
+
+// shapeFromLabel returns the shape of a block on the board
+// every block placed by the engine is labelled with the kind of piece it belongs to
+func shapeFromLabel(label string) Shape {
+	kind, err := tetrimino.KindFromName(label)
+	if err != nil {
+		return Empty
+	}
+	return shapeOf(kind)
+}
+
+// Point is a position on the board, with (0, 0) being the bottom left corner
+type Point struct {
+	X, Y int
+}
+
+// Piece is a piece on the board
+type Piece struct {
+	Shape  Shape
+	Blocks []Point
+}
+
+func newPiece(piece tetrimino.Tetrimino) Piece {
+	p := Piece{Shape: shapeOf(piece.Kind())}
+	topL := piece.ContainingBox().TopLeft
+	for i, row := range piece.Blocks() {
+		for j, block := range row {
+			if block != nil {
+				p.Blocks = append(p.Blocks, Point{X: topL.X + j, Y: topL.Y - i})
+			}
+		}
+	}
+	return p
+}
+
+// Board is the blocks which have been locked in place, not including the current piece
+type Board struct {
+	Width      int
+	Height     int // the number of visible rows
+	HiddenRows int // the number of rows above the visible field, where pieces spawn
+	// Cells[y][x] is the shape of the block at (x, y), including the hidden rows
+	Cells [][]Shape
+}
+
+// At returns the shape of the block at the point, Empty if there isn't one or the point isn't on the board
+func (b Board) At(p Point) Shape {
+	if p.Y < 0 || p.Y >= len(b.Cells) || p.X < 0 || p.X >= b.Width {
+		return Empty
+	}
+	return b.Cells[p.Y][p.X]
+}
+
+func newBoard(e *game.Engine) Board {
+	var (
+		blocks  = e.Board().Blocks
+		current = map[Point]bool{}
+	)
+	for _, p := range newPiece(e.CurrentPiece()).Blocks {
+		current[p] = true
+	}
+
+	b := Board{
+		Width:      len(blocks[0]),
+		Height:     len(blocks) - e.Board().HiddenRows(),
+		HiddenRows: e.Board().HiddenRows(),
+		Cells:      make([][]Shape, len(blocks)),
+	}
+	for y, row := range blocks {
+		b.Cells[y] = make([]Shape, len(row))
+		for x, block := range row {
+			if block != nil && !current[Point{X: x, Y: y}] {
+				b.Cells[y][x] = shapeFromLabel(block.Label)
+			}
+		}
+	}
+	return b
+}
+
+// EndReason describes why a game ended
+type EndReason int
+
+// the possible reasons for a game ending
+const (
+	NotOver EndReason = iota
+	// BlockOut means a newly spawned piece overlapped an existing block
+	BlockOut
+	// LockOut means a piece was locked entirely above the visible field
+	LockOut
+	// PartialLockOut means a piece was locked partially above the visible field
+	PartialLockOut
+	// GoalReached means the line goal for the mode was reached
+	GoalReached
+	// TimeUp means the time limit for the mode was reached
+	TimeUp
+)
+
+var internalReasons = map[game.EndReason]EndReason{
+	game.NotOver:        NotOver,
+	game.BlockOut:       BlockOut,
+	game.LockOut:        LockOut,
+	game.PartialLockOut: PartialLockOut,
+	game.GoalReached:    GoalReached,
+	game.TimeUp:         TimeUp,
+}
+
+func (e EndReason) String() string {
+	for internal, reason := range internalReasons {
+		if reason == e {
+			return internal.String()
+		}
+	}
+	return ""
+}
+
+// MarshalText encodes the reason using its description
+func (e EndReason) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// State is a snapshot of a game
+type State struct {
+	Board     Board
+	Current   Piece
+	Ghost     Piece   // where the current piece would land if hard dropped
+	Next      []Shape // the pieces which will be spawned next, in order
+	Score     int
+	Level     int
+	Lines     int
+	EndReason EndReason
+}
+
+// Over reports whether the game has ended
+func (s State) Over() bool {
+	return s.EndReason != NotOver
+}
+
+func newState(e *game.Engine) State {
+	s := State{
+		Board:     newBoard(e),
+		Current:   newPiece(e.CurrentPiece()),
+		Score:     e.Score(),
+		Level:     e.Level(),
+		Lines:     e.Lines(),
+		EndReason: internalReasons[e.EndReason()],
+	}
+	if ghost := e.GhostPiece(); ghost != nil {
+		s.Ghost = newPiece(ghost)
+	}
+	for _, piece := range e.NextPieces() {
+		s.Next = append(s.Next, shapeOf(piece.Kind()))
+	}
+	return s
+}
+
+// Stats tracks how a game has been played
+type Stats struct {
+	Pieces      int           `json:"pieces"` // the number of pieces locked in place
+	PieceCounts map[Shape]int `json:"piece-counts"`
+	Singles     int           `json:"singles"`
+	Doubles     int           `json:"doubles"`
+	Triples     int           `json:"triples"`
+	Tetrises    int           `json:"tetrises"`
+}
+
+// Result is the outcome of a game
+type Result struct {
+	Score    int           `json:"score"`
+	Lines    int           `json:"lines"`
+	Level    int           `json:"level"`
+	Duration time.Duration `json:"duration"` // the game time which passed, see Game.Advance
+	Stats    Stats         `json:"stats"`
+	Reason   EndReason     `json:"reason"`
+	Seed     int64         `json:"seed"` // passing this to WithSeed plays the same pieces again
+}
+
+// MarshalJSON encodes the result, with the duration in seconds rather than nanoseconds
+func (r Result) MarshalJSON() ([]byte, error) {
+	// the alias doesn't have any methods, so it doesn't recursively call MarshalJSON
+	type result Result
+	return json.Marshal(struct {
+		result
+		Duration float64 `json:"duration"`
+	}{
+		result:   result(r),
+		Duration: r.Duration.Seconds(),
+	})
+}
+
+func newResult(r game.Result) Result {
+	result := Result{
+		Score:    r.Score,
+		Lines:    r.Lines,
+		Level:    r.Level,
+		Duration: r.Duration,
+		Stats: Stats{
+			Pieces:      r.Stats.Pieces,
+			PieceCounts: map[Shape]int{},
+			Singles:     r.Stats.Singles,
+			Doubles:     r.Stats.Doubles,
+			Triples:     r.Stats.Triples,
+			Tetrises:    r.Stats.Tetrises,
+		},
+		Reason: internalReasons[r.Reason],
+		Seed:   r.Seed,
+	}
+	for kind, count := range r.Stats.PieceCounts {
+		result.Stats.PieceCounts[shapeOf(kind)] = count
+	}
+	return result
+}